@@ -0,0 +1,38 @@
+package logf
+
+//WithAssertPanic returns function for setting panic on failed assertion of Assert method.
+func WithAssertPanic(p bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.assertPanic = p
+	}
+}
+
+//assertFailed prints message of failed assertion at ERROR level, and panics if WithAssertPanic option is set.
+func (l *Logger) assertFailed(format string, v ...interface{}) {
+	s := l.message(l.sprintf(format, v...))
+	_ = l.Output(ERROR, l.depth, s)
+	l.mu.Lock()
+	p := l.assertPanic
+	l.mu.Unlock()
+	if p {
+		_ = l.flush()
+		panic(s)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,98 @@
+package logf
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+//Block prints lines at level lv as contiguous events in the output
+//(lines of other goroutines are not interleaved). Each line is formatted as a separate event.
+//Lines are also written to sinks, hooks, memory buffer and tee loggers as separate events.
+func (l *Logger) Block(lv Level, lines ...string) error {
+	return l.block(lv, l.depth-1, lines)
+}
+
+//block prints lines at level lv as contiguous events in the output of l and dispatches them to the others.
+//calldepth is the same as l.lg.Output().
+func (l *Logger) block(lv Level, calldepth int, lines []string) error {
+	if len(lines) == 0 || l.drop(len(lines)) {
+		return nil
+	}
+	msgs := make([]string, len(lines))
+	for i, line := range lines {
+		msgs[i] = l.message(line)
+	}
+	err := l.blockEvents(lv, calldepth+1, msgs)
+	for _, t := range l.teeLoggers() {
+		if e := t.block(lv, calldepth+1, lines); err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+//blockEvents filters and writes messages as contiguous events to the output, sinks, hooks and memory buffer of l.
+func (l *Logger) blockEvents(lv Level, calldepth int, msgs []string) error {
+	if !lv.GTE(l.minLevel()) {
+		atomic.AddUint64(&l.filtered, uint64(len(msgs)))
+		fields := levelFields(lv, l.fields.list())
+		for _, msg := range msgs {
+			l.rememberFiltered(lv, msg, fields)
+		}
+		return nil
+	}
+	fields := l.eventFields(lv)
+	if !l.filterFields(fields) {
+		return nil
+	}
+	for _, msg := range msgs {
+		l.remember(lv, msg, fields)
+		l.callHooks(lv, msg, fields)
+	}
+	var err error
+	if l.writable(lv) {
+		err = l.writeBlock(lv, calldepth+1, msgs, fields)
+	}
+	for _, msg := range msgs {
+		if e := l.writeSinks(lv, calldepth+1, msg, fields); err == nil {
+			err = e
+		}
+	}
+	if e := l.flushOnLevel(lv); err == nil {
+		err = e
+	}
+	if err != nil {
+		l.handleError(fmt.Errorf("%w: %v", ErrWriteFailed, err))
+	}
+	return err
+}
+
+//writeBlock writes messages as contiguous events to the output of l (holding the lock while writing all of them).
+func (l *Logger) writeBlock(lv Level, calldepth int, msgs []string, fields []Field) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	w := l.writerFor(lv)
+	var b []byte
+	for _, msg := range msgs {
+		b = append(b, l.render(w, lv, calldepth, msg, fields, true)...)
+	}
+	return l.writeTo(w, lv, b)
+}
+
+//Block calls std.Block() to print lines to the logger.
+func Block(lv Level, lines ...string) error { return std.Block(lv, lines...) }
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
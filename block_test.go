@@ -0,0 +1,122 @@
+package logf
+
+import (
+	"bytes"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBlock(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel|Lshortfile), WithPrefix("app: "), WithMinLevel(INFO))
+	l.Block(WARN, "one", "two\n")
+	l.Block(DEBUG, "filtered")
+	res := "app: block_test.go:15: [WARN] one\napp: block_test.go:15: [WARN] two\n"
+	if s := buf.String(); s != res {
+		t.Errorf("Block() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestBlockConcurrency(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriterFunc(func(p []byte) (int, error) {
+		runtime.Gosched() //let other writers run into the critical section
+		return buf.Write(p)
+	}), WithFlags(Llevel))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				l.Block(INFO, "block 1", "block 2", "block 3")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				l.Print("other")
+			}
+		}()
+	}
+	wg.Wait()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4*1000*4 {
+		t.Fatalf("Block() wrote %d lines, want %d.", len(lines), 4*1000*4)
+	}
+	for i := 0; i < len(lines); i++ {
+		switch {
+		case lines[i] == "[INFO] other":
+		case lines[i] == "[INFO] block 1" && i+2 < len(lines) && lines[i+1] == "[INFO] block 2" && lines[i+2] == "[INFO] block 3":
+			i += 2
+		default:
+			t.Fatalf("Block() is split at line %d: %v", i, lines[i:])
+		}
+	}
+}
+
+func TestBlockGroup(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel)).WithGroup("http").WithFields(Fields{"method": "GET"})
+	if err := l.Block(INFO, "one", "two"); err != nil {
+		t.Errorf("Block() = \"%v\", want nil.", err)
+	}
+	if s, res := buf.String(), "[INFO] one http.method=GET\n[INFO] two http.method=GET\n"; s != res {
+		t.Errorf("Block() = %q, want %q.", s, res)
+	}
+}
+
+func TestBlockDispatch(t *testing.T) {
+	buf, sk, tb := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+	var hooked []string
+	l := New(
+		WithWriter(buf),
+		WithFlags(Llevel),
+		WithMinLevel(INFO),
+		WithMinLevelForWriter(FATAL),
+		WithSink(TEXT, sk),
+		WithMemoryBuffer(4),
+		WithHook(TRACE, func(lv Level, msg string, fields []Field) { hooked = append(hooked, msg) }),
+	)
+	l.Tee(New(WithWriter(tb), WithFlags(Llevel)))
+	if err := l.Block(ERROR, "one", "two"); err != nil {
+		t.Errorf("Block() = \"%v\", want nil.", err)
+	}
+	_ = l.Block(DEBUG, "filtered")
+	if buf.Len() != 0 {
+		t.Errorf("Block() output = \"%v\", want \"\".", buf.String())
+	}
+	if s, res := sk.String(), "[ERROR] one\n[ERROR] two\n"; s != res {
+		t.Errorf("Block() sink = \"%v\", want \"%v\".", s, res)
+	}
+	if s, res := tb.String(), "[ERROR] one\n[ERROR] two\n[DEBUG] filtered\n"; s != res {
+		t.Errorf("Block() tee = \"%v\", want \"%v\".", s, res)
+	}
+	if res := []string{"one", "two"}; !reflect.DeepEqual(hooked, res) {
+		t.Errorf("Block() hooks = %v, want %v.", hooked, res)
+	}
+	if dump, res := l.Dump(), []string{"[ERROR] one", "[ERROR] two", "[DEBUG] filtered"}; !reflect.DeepEqual(dump, res) {
+		t.Errorf("Block() memory = %v, want %v.", dump, res)
+	}
+	if n := l.Filtered(); n != 1 {
+		t.Errorf("Filtered() = %d, want 1.", n)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
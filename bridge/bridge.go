@@ -0,0 +1,122 @@
+//Package bridge provides conversions between logf.Level and levels of other loggers
+//(logrus and zap) without dependency on them.
+//Values are numbers of logrus.Level (uint32) and zapcore.Level (int8), e.g.
+//
+//	lv := logrus.Level(bridge.ToLogrusLevel(logf.INFO))
+//	lv := bridge.FromZapLevel(int8(zapcore.WarnLevel))
+package bridge
+
+import "github.com/spiegel-im-spiegel/logf"
+
+//Levels of logrus (logrus.Level)
+const (
+	LogrusPanic uint32 = iota
+	LogrusFatal
+	LogrusError
+	LogrusWarn
+	LogrusInfo
+	LogrusDebug
+	LogrusTrace
+)
+
+//Levels of zap (zapcore.Level)
+const (
+	ZapDebug int8 = iota - 1
+	ZapInfo
+	ZapWarn
+	ZapError
+	ZapDPanic
+	ZapPanic
+	ZapFatal
+)
+
+var logrusLevels = map[logf.Level]uint32{
+	logf.TRACE: LogrusTrace,
+	logf.DEBUG: LogrusDebug,
+	logf.INFO:  LogrusInfo,
+	logf.WARN:  LogrusWarn,
+	logf.ERROR: LogrusError,
+	logf.FATAL: LogrusFatal,
+}
+
+var zapLevels = map[logf.Level]int8{
+	logf.TRACE: ZapDebug,
+	logf.DEBUG: ZapDebug,
+	logf.INFO:  ZapInfo,
+	logf.WARN:  ZapWarn,
+	logf.ERROR: ZapError,
+	logf.FATAL: ZapFatal,
+}
+
+//ToLogrusLevel returns logrus level of lv. Unknown level is regarded as INFO.
+//It round-trips with FromLogrusLevel.
+func ToLogrusLevel(lv logf.Level) uint32 {
+	if l, ok := logrusLevels[lv]; ok {
+		return l
+	}
+	return LogrusInfo
+}
+
+//FromLogrusLevel returns logf.Level of logrus level lv.
+//LogrusPanic is converted to FATAL (lossy), and unknown level is regarded as INFO.
+func FromLogrusLevel(lv uint32) logf.Level {
+	switch lv {
+	case LogrusPanic, LogrusFatal:
+		return logf.FATAL
+	case LogrusError:
+		return logf.ERROR
+	case LogrusWarn:
+		return logf.WARN
+	case LogrusInfo:
+		return logf.INFO
+	case LogrusDebug:
+		return logf.DEBUG
+	case LogrusTrace:
+		return logf.TRACE
+	default:
+		return logf.INFO
+	}
+}
+
+//ToZapLevel returns zap level of lv. Unknown level is regarded as INFO.
+//TRACE is converted to ZapDebug (lossy; zap has no trace level).
+func ToZapLevel(lv logf.Level) int8 {
+	if l, ok := zapLevels[lv]; ok {
+		return l
+	}
+	return ZapInfo
+}
+
+//FromZapLevel returns logf.Level of zap level lv.
+//ZapDPanic and ZapPanic are converted to FATAL (lossy), and unknown level is regarded as INFO.
+func FromZapLevel(lv int8) logf.Level {
+	switch lv {
+	case ZapDebug:
+		return logf.DEBUG
+	case ZapInfo:
+		return logf.INFO
+	case ZapWarn:
+		return logf.WARN
+	case ZapError:
+		return logf.ERROR
+	case ZapDPanic, ZapPanic, ZapFatal:
+		return logf.FATAL
+	default:
+		return logf.INFO
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,78 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/spiegel-im-spiegel/logf"
+)
+
+func TestLogrusLevel(t *testing.T) {
+	testCase := []struct {
+		lv     logf.Level
+		logrus uint32
+	}{
+		{lv: logf.TRACE, logrus: LogrusTrace},
+		{lv: logf.DEBUG, logrus: LogrusDebug},
+		{lv: logf.INFO, logrus: LogrusInfo},
+		{lv: logf.WARN, logrus: LogrusWarn},
+		{lv: logf.ERROR, logrus: LogrusError},
+		{lv: logf.FATAL, logrus: LogrusFatal},
+	}
+	for _, tst := range testCase {
+		if l := ToLogrusLevel(tst.lv); l != tst.logrus {
+			t.Errorf("ToLogrusLevel(%v)  = %v, want %v.", tst.lv, l, tst.logrus)
+		}
+		if lv := FromLogrusLevel(tst.logrus); lv != tst.lv {
+			t.Errorf("FromLogrusLevel(%v)  = %v, want %v.", tst.logrus, lv, tst.lv)
+		}
+	}
+	if lv := FromLogrusLevel(LogrusPanic); lv != logf.FATAL {
+		t.Errorf("FromLogrusLevel(%v)  = %v, want %v.", LogrusPanic, lv, logf.FATAL)
+	}
+	if lv := FromLogrusLevel(LogrusTrace + 1); lv != logf.INFO {
+		t.Errorf("FromLogrusLevel(%v)  = %v, want %v.", LogrusTrace+1, lv, logf.INFO)
+	}
+}
+
+func TestZapLevel(t *testing.T) {
+	testCase := []struct {
+		lv  logf.Level
+		zap int8
+		rev logf.Level
+	}{
+		{lv: logf.TRACE, zap: ZapDebug, rev: logf.DEBUG},
+		{lv: logf.DEBUG, zap: ZapDebug, rev: logf.DEBUG},
+		{lv: logf.INFO, zap: ZapInfo, rev: logf.INFO},
+		{lv: logf.WARN, zap: ZapWarn, rev: logf.WARN},
+		{lv: logf.ERROR, zap: ZapError, rev: logf.ERROR},
+		{lv: logf.FATAL, zap: ZapFatal, rev: logf.FATAL},
+	}
+	for _, tst := range testCase {
+		if l := ToZapLevel(tst.lv); l != tst.zap {
+			t.Errorf("ToZapLevel(%v)  = %v, want %v.", tst.lv, l, tst.zap)
+		}
+		if lv := FromZapLevel(tst.zap); lv != tst.rev {
+			t.Errorf("FromZapLevel(%v)  = %v, want %v.", tst.zap, lv, tst.rev)
+		}
+	}
+	for _, l := range []int8{ZapDPanic, ZapPanic} {
+		if lv := FromZapLevel(l); lv != logf.FATAL {
+			t.Errorf("FromZapLevel(%v)  = %v, want %v.", l, lv, logf.FATAL)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,58 @@
+package logf
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+//WithBuildInfo returns function for emitting build metadata of application in every event.
+//It is rendered as "version" and "commit" fields by Formatter, or as "[version commit] " tag in text format.
+//If version is empty, the main module version from runtime/debug.ReadBuildInfo() is used (if any).
+func WithBuildInfo(version, commit string) OptFunc {
+	return func(l *Logger) {
+		if len(version) == 0 {
+			if bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Version != "(devel)" {
+				version = bi.Main.Version
+			}
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.version = version
+		l.commit = commit
+	}
+}
+
+//buildTag returns "[version commit] " tag (empty if no build metadata).
+func (l *Logger) buildTag() string {
+	if len(l.version) == 0 && len(l.commit) == 0 {
+		return ""
+	}
+	return "[" + strings.TrimSpace(l.version+" "+l.commit) + "] "
+}
+
+//buildFields returns build metadata as structured fields.
+func (l *Logger) buildFields() []Field {
+	fields := []Field{}
+	if len(l.version) > 0 {
+		fields = append(fields, Field{Key: "version", Value: l.version})
+	}
+	if len(l.commit) > 0 {
+		fields = append(fields, Field{Key: "commit", Value: l.commit})
+	}
+	return fields
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,46 @@
+package logf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWithBuildInfo(t *testing.T) {
+	textBuf := new(bytes.Buffer)
+	l := New(WithWriter(textBuf), WithFlags(Llevel), WithBuildInfo("v1.2.3", "abc1234"), WithPID())
+	l.Print("hello")
+	if s := textBuf.String(); !strings.HasPrefix(s, "[v1.2.3 abc1234] [") || !strings.HasSuffix(s, "] [INFO] hello\n") {
+		t.Errorf("WithBuildInfo() = \"%v\", want \"[v1.2.3 abc1234] [pid] [INFO] hello\".", s)
+	}
+
+	textBuf.Reset()
+	l = New(WithWriter(textBuf), WithFlags(Llevel), WithBuildInfo("", "abc1234"))
+	l.Print("hello")
+	if s := textBuf.String(); !strings.HasSuffix(s, "abc1234] [INFO] hello\n") {
+		t.Errorf("WithBuildInfo() = \"%v\", want commit tag.", s)
+	}
+
+	jsonBuf := new(bytes.Buffer)
+	l = New(WithWriter(jsonBuf), WithFormat(JSON), WithBuildInfo("v1.2.3", "abc1234"))
+	l.Print("hello")
+	res := `"msg":"hello","version":"v1.2.3","commit":"abc1234"}`
+	if s := jsonBuf.String(); !strings.HasSuffix(s, res+"\n") {
+		t.Errorf("WithBuildInfo() = \"%v\", want suffix \"%v\".", s, res)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,112 @@
+package logf
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//burstSummaryLevel is level of summary events of WithBurstSummary option.
+const burstSummaryLevel = WARN
+
+//burstState is state of burst summary in current 1-second window.
+type burstState struct {
+	mu    sync.Mutex
+	limit int         // max number of events per second
+	start time.Time   // start of window
+	count int         // number of events in window
+	last  *burstEvent // last suppressed event in window
+}
+
+//burstEvent is suppressed event.
+type burstEvent struct {
+	lv     Level
+	s      string
+	fields []Field
+}
+
+//WithBurstSummary returns function for collapsing bursts of events (regardless of content).
+//Within a 1-second window, the first perSecond events are emitted and others are suppressed;
+//after the window ends, the last suppressed event is emitted and then "N messages in last second" summary at WARN level
+//(checked at next event, Sync() or Close(); there is no timer, so a quiet logger keeps the summary pending).
+//If perSecond is 0 or less, it is disabled.
+func WithBurstSummary(perSecond int) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if perSecond <= 0 {
+			l.bursts = nil
+			return
+		}
+		l.bursts = &burstState{limit: perSecond}
+	}
+}
+
+//burst counts the event and reports whether it is emitted.
+//The summary of previous window is written first if the window ended.
+func (l *Logger) burst(lv Level, calldepth int, s string, fields []Field) bool {
+	l.mu.Lock()
+	b := l.bursts
+	var now time.Time
+	if b != nil {
+		now = l.clock()
+	}
+	l.mu.Unlock()
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	var last *burstEvent
+	var n int
+	if b.start.IsZero() || now.Sub(b.start) >= time.Second {
+		last, n = b.last, b.count
+		b.start, b.count, b.last = now, 0, nil
+	}
+	b.count++
+	emit := b.count <= b.limit
+	if !emit {
+		b.last = &burstEvent{lv: lv, s: s, fields: append([]Field{}, fields...)}
+	}
+	b.mu.Unlock()
+	l.summarize(calldepth+1, last, n)
+	return emit
+}
+
+//flushBurst writes the summary of current window (if any events are suppressed).
+func (l *Logger) flushBurst() {
+	l.mu.Lock()
+	b := l.bursts
+	l.mu.Unlock()
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	last, n := b.last, b.count
+	b.start, b.count, b.last = time.Time{}, 0, nil
+	b.mu.Unlock()
+	l.summarize(2, last, n)
+}
+
+//summarize writes the last suppressed event and the summary of n events.
+func (l *Logger) summarize(calldepth int, last *burstEvent, n int) {
+	if last == nil {
+		return
+	}
+	_ = l.dispatch(last.lv, calldepth+1, last.s, last.fields)
+	_ = l.dispatch(burstSummaryLevel, calldepth+1, fmt.Sprintf("%d messages in last second", n), nil)
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
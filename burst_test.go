@@ -0,0 +1,57 @@
+package logf
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWithBurstSummary(t *testing.T) {
+	start := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	now := start
+	clock := func() time.Time { return now }
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel), WithClock(clock), WithBurstSummary(2))
+	for i := 1; i <= 10; i++ {
+		now = start.Add(time.Duration(i) * 10 * time.Millisecond)
+		l.Printf("event %d", i)
+	}
+	now = start.Add(1500 * time.Millisecond)
+	l.Print("after burst")
+	res := "[INFO] event 1\n[INFO] event 2\n[INFO] event 10\n[WARN] 10 messages in last second\n[INFO] after burst\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WithBurstSummary() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestWithBurstSummaryFlush(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel), WithClock(func() time.Time { return now }), WithBurstSummary(1))
+	for i := 1; i <= 3; i++ {
+		l.Error(fmt.Sprint("event ", i))
+	}
+	if err := l.Sync(); err != nil {
+		t.Errorf("Logger.Sync() = \"%v\", want nil.", err)
+	}
+	res := "[ERROR] event 1\n[ERROR] event 3\n[WARN] 3 messages in last second\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WithBurstSummary() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,72 @@
+package logf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//captureBuffer is goroutine-safe buffer of Capture method.
+type captureBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+//Write is io.Writer method.
+func (b *captureBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+//lines returns written lines.
+func (b *captureBuffer) lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := strings.TrimSuffix(b.buf.String(), "\n")
+	if len(s) == 0 {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+//Capture redirects output of the logger to internal buffer while fn runs,
+//and returns the captured lines. The previous writer is restored after that.
+//If fn panics, Capture returns lines captured so far and ErrCapturePanic.
+func (l *Logger) Capture(fn func()) (lines []string, err error) {
+	buf := &captureBuffer{}
+	l.mu.Lock()
+	w := l.lg.Writer()
+	l.lg.SetOutput(buf)
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		l.lg.SetOutput(w)
+		l.mu.Unlock()
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrCapturePanic, r)
+		}
+		lines = buf.lines()
+	}()
+	fn()
+	return nil, nil
+}
+
+//Capture calls std.Capture() to capture output of the logger.
+func Capture(fn func()) ([]string, error) { return std.Capture(fn) }
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
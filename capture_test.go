@@ -0,0 +1,69 @@
+package logf
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCapture(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel))
+	l.Print("before")
+	lines, err := l.Capture(func() {
+		l.Print("one")
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Output(WARN, 1, "two")
+		}()
+		wg.Wait()
+	})
+	if err != nil {
+		t.Errorf("Capture() = \"%v\", want nil.", err)
+	}
+	if want := []string{"[INFO] one", "[WARN] two"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("Capture() = %v, want %v.", lines, want)
+	}
+	l.Print("after")
+	if s, want := buf.String(), "[INFO] before\n[INFO] after\n"; s != want {
+		t.Errorf("output = \"%v\", want \"%v\".", s, want)
+	}
+}
+
+func TestCapturePanic(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel))
+	lines, err := l.Capture(func() {
+		l.Print("one")
+		panic("boom")
+	})
+	if !errors.Is(err, ErrCapturePanic) {
+		t.Errorf("Capture() = \"%v\", want \"%v\".", err, ErrCapturePanic)
+	}
+	if want := []string{"[INFO] one"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("Capture() = %v, want %v.", lines, want)
+	}
+	l.Print("after")
+	if s, want := buf.String(), "[INFO] after\n"; s != want {
+		t.Errorf("output = \"%v\", want \"%v\".", s, want)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
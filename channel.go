@@ -0,0 +1,77 @@
+package logf
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+//chanWriter is writer sending each logging event to channel.
+type chanWriter struct {
+	dropped uint64 // first for atomic alignment
+	ch      chan<- string
+}
+
+//WithChannel returns function for sending each logging event (without trailing newline) also to ch.
+//Sending does not block: if ch is full (or closed), the event is dropped and counted by ChannelDropped method.
+//The event is formatted by formatter of the logger.
+func WithChannel(ch chan<- string) OptFunc {
+	return func(l *Logger) {
+		if ch == nil {
+			return
+		}
+		w := &chanWriter{ch: ch}
+		l.mu.Lock()
+		l.chw = w
+		l.sinks = append(l.sinks, &sink{inherit: true, w: w})
+		l.mu.Unlock()
+	}
+}
+
+//ChannelDropped returns number of events dropped because the channel of WithChannel option is full or closed.
+func (l *Logger) ChannelDropped() uint64 {
+	l.mu.Lock()
+	w := l.chw
+	l.mu.Unlock()
+	if w == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&w.dropped)
+}
+
+//Write is io.Writer method.
+func (w *chanWriter) Write(b []byte) (int, error) {
+	if !w.send(strings.TrimSuffix(string(b), "\n")) {
+		atomic.AddUint64(&w.dropped, 1)
+	}
+	return len(b), nil
+}
+
+//send sends s to the channel without blocking. It returns false if s is dropped.
+func (w *chanWriter) send(s string) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil { // closed channel
+			ok = false
+		}
+	}()
+	select {
+	case w.ch <- s:
+		return true
+	default:
+		return false
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,55 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWithChannel(t *testing.T) {
+	ch := make(chan string, 2)
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel), WithChannel(ch))
+	l.Print("one")
+	l.Print("two")
+	l.Print("three")
+	for _, want := range []string{"[INFO] one", "[INFO] two"} {
+		if s := <-ch; s != want {
+			t.Errorf("received line = \"%v\", want \"%v\".", s, want)
+		}
+	}
+	if n := l.ChannelDropped(); n != 1 {
+		t.Errorf("ChannelDropped() = %d, want 1.", n)
+	}
+	if s, want := buf.String(), "[INFO] one\n[INFO] two\n[INFO] three\n"; s != want {
+		t.Errorf("output = \"%v\", want \"%v\".", s, want)
+	}
+	close(ch)
+	l.Print("four")
+	if n := l.ChannelDropped(); n != 2 {
+		t.Errorf("ChannelDropped() = %d, want 2.", n)
+	}
+}
+
+func TestWithChannelFormatter(t *testing.T) {
+	ch := make(chan string, 1)
+	l := New(WithWriter(&bytes.Buffer{}), WithChannel(ch), WithFormatter(testFormatter{}))
+	l.Print("hello")
+	if s, want := <-ch, "INFO|hello"; s != want {
+		t.Errorf("received line = \"%v\", want \"%v\".", s, want)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
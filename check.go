@@ -0,0 +1,54 @@
+package logf
+
+import "io"
+
+//Check verifies that the writers of the logger (output, writers of WithLevelRangeOutput and WithSink options) are writable.
+//It writes empty probe (no bytes) to each writer, so no visible log entry is emitted,
+//and returns the first error (e.g. closed file of WithOutputFile option).
+//Probes are serialized with other logging events (and writes of loggers sharing the mutex of WithSharedMutex option).
+func (l *Logger) Check() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.shared != nil {
+		l.shared.Lock()
+		defer l.shared.Unlock()
+	}
+	err := probeWriter(l.lg.Writer())
+	for _, r := range l.routes {
+		if e := probeWriter(r.w); err == nil {
+			err = e
+		}
+	}
+	for _, sk := range l.sinks {
+		if e := probeWriter(sk.w); err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+//Check calls std.Check() to verify the writers of the logger.
+func Check() error {
+	return std.Check()
+}
+
+//probeWriter writes empty probe to w.
+func probeWriter(w io.Writer) error {
+	_, err := w.Write([]byte{})
+	return err
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
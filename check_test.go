@@ -0,0 +1,99 @@
+package logf
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf))
+	if err := l.Check(); err != nil {
+		t.Errorf("Logger.Check() = \"%v\", want nil.", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Logger.Check() writes \"%v\", want nothing.", buf.String())
+	}
+	testCase := []*Logger{
+		New(WithWriter(brokenWriter{})),
+		New(WithWriter(buf), WithSink(JSON, brokenWriter{})),
+		New(WithWriter(buf), WithLevelRangeOutput(ERROR, FATAL, brokenWriter{})),
+	}
+	for i, l := range testCase {
+		if err := l.Check(); !errors.Is(err, errBrokenWriter) {
+			t.Errorf("Logger.Check() #%d = \"%v\", want \"%v\".", i, err, errBrokenWriter)
+		}
+	}
+}
+
+func TestCheckClosedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	f, err := os.Create(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(WithWriter(f))
+	if err := l.Check(); err != nil {
+		t.Errorf("Logger.Check() = \"%v\", want nil.", err)
+	}
+	f.Close()
+	if err := l.Check(); err == nil {
+		t.Error("Logger.Check() with closed file = nil, want error.")
+	}
+}
+
+func TestCheckConcurrency(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriterFunc(func(p []byte) (int, error) {
+		n, _ := buf.Write(p[:len(p)/2])
+		runtime.Gosched() //let other writers run into the critical section
+		m, err := buf.Write(p[len(p)/2:])
+		return n + m, err
+	}), WithFlags(Llevel))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				_ = l.Check()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				l.Print("other")
+			}
+		}()
+	}
+	wg.Wait()
+	if s, want := buf.String(), strings.Repeat("[INFO] other\n", 4*1000); s != want {
+		t.Errorf("Logger.Check() breaks output: %q", s)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
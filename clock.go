@@ -0,0 +1,114 @@
+package logf
+
+import (
+	"fmt"
+	"time"
+)
+
+//WithClock returns function for setting clock of time stamp (default time.Now).
+//It is useful for tests.
+func WithClock(now func() time.Time) OptFunc {
+	return func(l *Logger) {
+		if now == nil {
+			return
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.now = now
+	}
+}
+
+//clock returns current time by the clock. It must be called with l.mu held.
+func (l *Logger) clock() time.Time {
+	if l.now != nil {
+		return l.now()
+	}
+	return time.Now()
+}
+
+//WithCompactTime returns function for printing date only when it changes from the previous event
+//(time is printed in every event). It has effect on text format with Ldate flag only.
+func WithCompactTime(compact bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.compact = compact
+		l.lastDate = ""
+	}
+}
+
+//compactFlag returns flags without Ldate if date of t is the same as the previous event.
+//It must be called with l.mu held.
+func (l *Logger) compactFlag(flag int, t time.Time) int {
+	if !l.compact || (flag&Ldate) == 0 {
+		return flag
+	}
+	if (flag & LUTC) != 0 {
+		t = t.UTC()
+	}
+	date := t.Format("2006/01/02")
+	if date == l.lastDate {
+		return flag &^ Ldate
+	}
+	l.lastDate = date
+	return flag
+}
+
+//WithRelativeTime returns function for printing time stamp relative to creation of the logger
+//(e.g. "+1.234s") instead of date and time in text format. Clock of WithClock option is used if set.
+func WithRelativeTime(relative bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.relative = relative
+	}
+}
+
+//WithDeltaTime returns function for printing time since the previous event (e.g. "+12ms") after time stamp
+//on the output of the logger in text format ("+0ms" for the first event). Clock of WithClock option is used if set.
+//Use it with flags without Ldate and Ltime to print it instead of time stamp.
+//Writers of WithSink option and Fprintf method do not print it.
+func WithDeltaTime(delta bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.deltaTime = delta
+		l.lastEvent = time.Time{}
+	}
+}
+
+//nextDelta returns time since the previous event (e.g. "+12ms ") for the event at t
+//and records t as time of the previous event. It returns empty string if WithDeltaTime option is not set.
+//It must be called with l.mu held, in the same critical section writing the event.
+func (l *Logger) nextDelta(t time.Time) string {
+	if !l.deltaTime {
+		return ""
+	}
+	var d time.Duration
+	if !l.lastEvent.IsZero() {
+		d = t.Sub(l.lastEvent)
+	}
+	l.lastEvent = t
+	return fmt.Sprintf("+%dms ", d/time.Millisecond)
+}
+
+//relativeTime returns time stamp of t relative to creation of the logger.
+//It must be called with l.mu held.
+func (l *Logger) relativeTime(t time.Time) string {
+	return fmt.Sprintf("+%.3fs ", t.Sub(l.start).Seconds())
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
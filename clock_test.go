@@ -0,0 +1,125 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+//testClock returns clock which returns times in order.
+func testClock(times ...time.Time) func() time.Time {
+	return func() time.Time {
+		t := times[0]
+		if len(times) > 1 {
+			times = times[1:]
+		}
+		return t
+	}
+}
+
+func TestWithCompactTime(t *testing.T) {
+	clock := testClock(
+		time.Date(2009, time.November, 10, 23, 59, 58, 0, time.UTC),
+		time.Date(2009, time.November, 10, 23, 59, 59, 0, time.UTC),
+		time.Date(2009, time.November, 11, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, time.November, 11, 0, 0, 1, 0, time.UTC),
+	)
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(LstdFlags|LUTC), WithClock(clock), WithCompactTime(true))
+	for i := 0; i < 4; i++ {
+		l.Print("tick")
+	}
+	res := "2009/11/10 23:59:58 [INFO] tick\n" +
+		"23:59:59 [INFO] tick\n" +
+		"2009/11/11 00:00:00 [INFO] tick\n" +
+		"00:00:01 [INFO] tick\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WithCompactTime(true) = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	clock := testClock(time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC))
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFormat(JSON), WithClock(clock))
+	l.Print("hello")
+	res := `{"time":"2009-11-10T23:00:00Z","level":"INFO","msg":"hello"}` + "\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WithClock() = \"%v\", want \"%v\".", s, res)
+	}
+	buf.Reset()
+	l = New(WithWriter(buf), WithFlags(LstdFlags|LUTC), WithClock(clock))
+	l.Print("hello")
+	res = "2009/11/10 23:00:00 [INFO] hello\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WithClock() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestWithRelativeTime(t *testing.T) {
+	start := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	clock := testClock(
+		start,
+		start.Add(1234*time.Millisecond),
+		start.Add(61*time.Second+5*time.Millisecond),
+	)
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(LstdFlags), WithPrefix("app: "), WithRelativeTime(true), WithClock(clock))
+	l.Print("one")
+	l.Print("two")
+	res := "app: +1.234s [INFO] one\n" +
+		"app: +61.005s [INFO] two\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WithRelativeTime() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestWithDeltaTime(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Ltime|Llevel), WithDeltaTime(true), WithClock(func() time.Time { return now }))
+	l.Print("one")
+	now = now.Add(12 * time.Millisecond)
+	l.Print("two")
+	now = now.Add(1500 * time.Millisecond)
+	l.SetFlags(Llevel)
+	l.Print("three")
+	res := "23:00:00 +0ms [INFO] one\n" +
+		"23:00:00 +12ms [INFO] two\n" +
+		"+1500ms [INFO] three\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WithDeltaTime() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestWithDeltaTimeBlockSink(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	buf := new(bytes.Buffer)
+	sk := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel), WithDeltaTime(true), WithSink(TEXT, sk), WithClock(func() time.Time { return now }))
+	l.Print("one")
+	now = now.Add(5 * time.Millisecond)
+	_ = l.Block(INFO, "two", "three")
+	res := "+0ms [INFO] one\n+5ms [INFO] two\n+0ms [INFO] three\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WithDeltaTime() = \"%v\", want \"%v\".", s, res)
+	}
+	if s, res := sk.String(), "[INFO] one\n[INFO] two\n[INFO] three\n"; s != res {
+		t.Errorf("WithDeltaTime() sink = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,126 @@
+package logf
+
+import (
+	"io"
+	"os"
+)
+
+//ColorMode is mode of colorized level token in text format.
+type ColorMode int
+
+//Values of ColorMode
+const (
+	ColorNever  ColorMode = iota //no color (default)
+	ColorAuto                    //colorize if the output is a terminal
+	ColorAlways                  //always colorize
+)
+
+//defaultLevelColors is default ANSI color (SGR parameters) of level token.
+var defaultLevelColors = map[Level]string{
+	TRACE: "90", //bright black
+	DEBUG: "36", //cyan
+	INFO:  "32", //green
+	WARN:  "33", //yellow
+	ERROR: "31", //red
+	FATAL: "35", //magenta
+}
+
+//WithColor returns function for setting color mode of level token.
+func WithColor(mode ColorMode) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.color = mode
+	}
+}
+
+//WithLevelColors returns function for overriding ANSI color (SGR parameters, e.g. "1;34") per level.
+//Levels not in colors keep default colors.
+func WithLevelColors(colors map[Level]string) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.levelColors == nil {
+			l.levelColors = map[Level]string{}
+		}
+		for lv, c := range colors {
+			l.levelColors[lv] = c
+		}
+	}
+}
+
+//WithColorFromLevel returns function for colorizing level token only at level lv or higher
+//(e.g. WARN, lower levels are plain). It has effect if color is enabled by WithColor option.
+func WithColorFromLevel(lv Level) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.colorFrom = lv
+	}
+}
+
+//colorEnabled returns true if level token is colorized.
+func (l *Logger) colorEnabled() bool {
+	return l.colorEnabledFor(l.lg.Writer())
+}
+
+//colorEnabledFor reports whether the level token written to w is colorized.
+func (l *Logger) colorEnabledFor(w io.Writer) bool {
+	switch l.color {
+	case ColorAlways:
+		return true
+	case ColorAuto:
+		return isTerminal(w)
+	default:
+		return false
+	}
+}
+
+//levelColor returns ANSI color of level.
+func (l *Logger) levelColor(lv Level) string {
+	if c, ok := l.levelColors[lv]; ok {
+		return c
+	}
+	return lv.Color()
+}
+
+//Color returns default ANSI color (SGR parameters, e.g. "32" for green) of lv (empty if unknown level).
+func (lv Level) Color() string {
+	return defaultLevelColors[lv]
+}
+
+//colorize wraps s by ANSI escape sequence of color.
+func colorize(s, color string) string {
+	if len(color) == 0 {
+		return s
+	}
+	return "\x1b[" + color + "m" + s + "\x1b[0m"
+}
+
+//isTerminal returns true if w is a character device (terminal).
+func isTerminal(w interface{}) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (info.Mode() & os.ModeCharDevice) != 0
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,114 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLevelColors(t *testing.T) {
+	testCase := []struct {
+		l Level
+		s string
+	}{
+		{l: TRACE, s: "\x1b[90m[TRACE]\x1b[0m Message\n"},
+		{l: DEBUG, s: "\x1b[36m[DEBUG]\x1b[0m Message\n"},
+		{l: INFO, s: "\x1b[1;34m[INFO]\x1b[0m Message\n"},
+		{l: WARN, s: "\x1b[33m[WARN]\x1b[0m Message\n"},
+		{l: ERROR, s: "\x1b[4;35m[ERROR]\x1b[0m Message\n"},
+		{l: FATAL, s: "\x1b[35m[FATAL]\x1b[0m Message\n"},
+		{l: FATAL + 1, s: "[] Message\n"},
+	}
+	for _, tst := range testCase {
+		outBuf := new(bytes.Buffer)
+		l := New(
+			WithWriter(outBuf),
+			WithFlags(Llevel),
+			WithColor(ColorAlways),
+			WithLevelColors(map[Level]string{INFO: "1;34", ERROR: "4;35"}),
+		)
+		_ = l.Output(tst.l, 2, "Message")
+		if s := outBuf.String(); s != tst.s {
+			t.Errorf("Logger.Output(%v)  = %q, want %q.", tst.l, s, tst.s)
+		}
+	}
+}
+
+func TestColorMode(t *testing.T) {
+	testCase := []struct {
+		mode ColorMode
+		s    string
+	}{
+		{mode: ColorNever, s: "[ERROR] Erroring\n"},
+		{mode: ColorAuto, s: "[ERROR] Erroring\n"}, //bytes.Buffer is not a terminal
+		{mode: ColorAlways, s: "\x1b[31m[ERROR]\x1b[0m Erroring\n"},
+	}
+	for _, tst := range testCase {
+		outBuf := new(bytes.Buffer)
+		l := New(
+			WithWriter(outBuf),
+			WithFlags(Llevel),
+			WithPrefix("[TEST] "),
+			WithColor(tst.mode),
+			WithMemoryBuffer(1),
+		)
+		l.Error("Erroring")
+		if s := outBuf.String(); s != "[TEST] "+tst.s {
+			t.Errorf("Logger.Error()  = %q, want %q.", s, "[TEST] "+tst.s)
+		}
+		if dump := l.Dump(); len(dump) != 1 || dump[0] != "[ERROR] Erroring" {
+			t.Errorf("Logger.Dump() = %q, want %q.", dump, []string{"[ERROR] Erroring"})
+		}
+	}
+}
+
+func TestWithColorFromLevel(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel), WithColor(ColorAlways), WithColorFromLevel(WARN), WithLevelColors(map[Level]string{ERROR: "1;31"}))
+	l.Print("info")
+	l.Warn("warn")
+	l.Error("error")
+	res := "[INFO] info\n\x1b[33m[WARN]\x1b[0m warn\n\x1b[1;31m[ERROR]\x1b[0m error\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("WithColorFromLevel() = %q, want %q.", s, res)
+	}
+}
+
+func TestLevelColor(t *testing.T) {
+	testCase := []struct {
+		l Level
+		s string
+	}{
+		{l: TRACE, s: "90"},
+		{l: DEBUG, s: "36"},
+		{l: INFO, s: "32"},
+		{l: WARN, s: "33"},
+		{l: ERROR, s: "31"},
+		{l: FATAL, s: "35"},
+		{l: FATAL + 1, s: ""},
+	}
+	for _, tst := range testCase {
+		if tst.l.Color() != tst.s {
+			t.Errorf("Level(%d).Color()  = \"%v\", want \"%v\".", int(tst.l), tst.l.Color(), tst.s)
+		}
+	}
+	for _, lv := range Levels() {
+		if len(lv.Short()) != 1 || len(lv.Color()) == 0 {
+			t.Errorf("Level(%d) has no short name or color.", int(lv))
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,146 @@
+package logf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//ErrInvalidConfig is returned by Configure function for invalid configuration.
+var ErrInvalidConfig = errors.New("invalid logger configuration")
+
+//Config is configuration of Logger read by Configure function.
+//
+//	{
+//		"level": "INFO",
+//		"format": "json",
+//		"flags": ["date", "time", "level", "shortfile"],
+//		"prefix": "[app] ",
+//		"output": "/var/log/app.log"
+//	}
+type Config struct {
+	Level  string   `json:"level"`  // minimum level (TRACE, DEBUG, INFO, WARN, ERROR or FATAL)
+	Format string   `json:"format"` // "text" (default), "json", "logfmt", "gelf" or "csv"
+	Flags  []string `json:"flags"`  // date, time, microseconds, longfile, shortfile, utc, level or std
+	Prefix string   `json:"prefix"` // prefix string
+	Output string   `json:"output"` // "stderr" (default), "stdout" or file path (appended, closed by Close method)
+}
+
+var configFlags = map[string]int{
+	"date":         Ldate,
+	"time":         Ltime,
+	"microseconds": Lmicroseconds,
+	"longfile":     Llongfile,
+	"shortfile":    Lshortfile,
+	"utc":          LUTC,
+	"level":        Llevel,
+	"std":          LstdFlags,
+}
+
+//Configure returns a new Logger configured by JSON data from r (see Config).
+//Unknown keys and values are reported as ErrInvalidConfig.
+func Configure(r io.Reader) (*Logger, error) {
+	cfg := Config{}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	opts, err := cfg.options()
+	if err != nil {
+		return nil, err
+	}
+	l := New(opts...)
+	if path := cfg.outputFile(); len(path) > 0 {
+		if err := l.openOutputFile(path, 0644, false); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+	}
+	return l, nil
+}
+
+//outputFile returns path of output file (empty if the output is stderr or stdout).
+func (cfg Config) outputFile() string {
+	switch strings.ToLower(cfg.Output) {
+	case "", "stderr", "stdout":
+		return ""
+	}
+	return cfg.Output
+}
+
+//options returns functional options of the configuration.
+func (cfg Config) options() ([]OptFunc, error) {
+	opts := []OptFunc{}
+	if len(cfg.Level) > 0 {
+		lv, ok := parseLevel(cfg.Level)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown level %q", ErrInvalidConfig, cfg.Level)
+		}
+		opts = append(opts, WithMinLevel(lv))
+	}
+	if len(cfg.Format) > 0 {
+		f, ok := parseFormat(cfg.Format)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidConfig, cfg.Format)
+		}
+		opts = append(opts, WithFormat(f))
+	}
+	if cfg.Flags != nil {
+		flag := 0
+		for _, name := range cfg.Flags {
+			f, ok := configFlags[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown flag %q", ErrInvalidConfig, name)
+			}
+			flag |= f
+		}
+		opts = append(opts, WithFlags(flag))
+	}
+	if len(cfg.Prefix) > 0 {
+		opts = append(opts, WithPrefix(cfg.Prefix))
+	}
+	switch strings.ToLower(cfg.Output) {
+	case "", "stderr":
+	case "stdout":
+		opts = append(opts, WithWriter(os.Stdout))
+	}
+	return opts, nil
+}
+
+//parseFormat returns Format of name (case-insensitive).
+func parseFormat(name string) (Format, bool) {
+	for f, s := range formatMap {
+		if strings.EqualFold(s, name) {
+			return f, true
+		}
+	}
+	return TEXT, false
+}
+
+//parseLevel returns Level of name (case-insensitive).
+func parseLevel(name string) (Level, bool) {
+	for _, lv := range Levels() {
+		if strings.EqualFold(lv.String(), name) {
+			return lv, true
+		}
+	}
+	return TRACE, false
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
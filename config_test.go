@@ -0,0 +1,78 @@
+package logf
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "app.log")
+	cfg := `{"level": "warn", "format": "json", "flags": ["date", "time", "UTC", "shortfile"], "prefix": "[app] ", "output": "` + filepath.ToSlash(path) + `"}`
+	l, err := Configure(strings.NewReader(cfg))
+	if err != nil {
+		t.Fatalf("Configure() = \"%v\", want nil.", err)
+	}
+	if lv := l.MinLevel(); lv != WARN {
+		t.Errorf("Configure() min level = %v, want %v.", lv, WARN)
+	}
+	if flag := l.Flags(); flag != Ldate|Ltime|LUTC|Lshortfile {
+		t.Errorf("Configure() flags = %#x, want %#x.", flag, Ldate|Ltime|LUTC|Lshortfile)
+	}
+	if prefix := l.GetLogger().Prefix(); prefix != "[app] " {
+		t.Errorf("Configure() prefix = \"%v\", want \"%v\".", prefix, "[app] ")
+	}
+	if _, ok := l.formatter.(JSONFormatter); !ok {
+		t.Errorf("Configure() formatter = %T, want JSONFormatter.", l.formatter)
+	}
+	l.Error("configured")
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); !strings.Contains(s, `"msg":"configured"`) {
+		t.Errorf("Configure() output = \"%v\", want JSON message.", s)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("Logger.Close() = \"%v\", want nil.", err)
+	}
+}
+
+func TestConfigureInvalid(t *testing.T) {
+	testCase := []string{
+		`{"level": "LOUD"}`,
+		`{"format": "xml"}`,
+		`{"flags": ["date", "color"]}`,
+		`{"unknown": true}`,
+		`{"level": 1}`,
+		`not json`,
+	}
+	for _, cfg := range testCase {
+		if _, err := Configure(strings.NewReader(cfg)); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("Configure(%s) = \"%v\", want \"%v\".", cfg, err, ErrInvalidConfig)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,57 @@
+package logf
+
+import "context"
+
+//requestLevelKey is key of request level in context.
+type requestLevelKey struct{}
+
+//WithRequestLevel returns copy of ctx with minimum level of the request.
+//Logger bound to the context by WithContext method prints events at lv or higher
+//even if minimum level of the logger is higher.
+func WithRequestLevel(ctx context.Context, lv Level) context.Context {
+	return context.WithValue(ctx, requestLevelKey{}, lv)
+}
+
+//RequestLevel returns minimum level of the request set by WithRequestLevel function.
+func RequestLevel(ctx context.Context) (Level, bool) {
+	lv, ok := ctx.Value(requestLevelKey{}).(Level)
+	return lv, ok
+}
+
+//WithContext returns child logger bound to ctx.
+//The child logger uses the lower of minimum level of the logger and the request level of ctx.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	child := l.with()
+	child.reqLevel = nil
+	if lv, ok := RequestLevel(ctx); ok {
+		child.reqLevel = &lv
+	}
+	return child
+}
+
+//WithContext returns child logger of std bound to ctx.
+func WithContext(ctx context.Context) *Logger { return std.WithContext(ctx) }
+
+//minLevel returns minimum level of events printed by l (including the request level).
+func (l *Logger) minLevel() Level {
+	min := l.MinLevel()
+	if l.reqLevel != nil && l.reqLevel.Below(min) {
+		return *l.reqLevel
+	}
+	return min
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
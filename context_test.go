@@ -0,0 +1,42 @@
+package logf
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestWithRequestLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel), WithMinLevel(INFO))
+	debugCtx := WithRequestLevel(context.Background(), DEBUG)
+	errorCtx := WithRequestLevel(context.Background(), ERROR)
+	if lv, ok := RequestLevel(debugCtx); !ok || lv != DEBUG {
+		t.Errorf("RequestLevel() = %v, %v, want %v, true.", lv, ok, DEBUG)
+	}
+
+	l.WithContext(debugCtx).Output(DEBUG, 1, "flagged")
+	l.WithContext(errorCtx).Output(DEBUG, 1, "normal")
+	l.WithContext(errorCtx).Output(INFO, 1, "normal")
+	l.WithContext(context.Background()).Output(DEBUG, 1, "normal")
+	l.Output(DEBUG, 1, "base")
+	res := "[DEBUG] flagged\n[INFO] normal\n"
+	if s := buf.String(); s != res {
+		t.Errorf("Output() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
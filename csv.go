@@ -0,0 +1,60 @@
+package logf
+
+import (
+	"bytes"
+	"encoding/csv"
+	"time"
+)
+
+//csvHeader is header line of CSV format.
+const csvHeader = "timestamp,level,message,fields\n"
+
+//CSVFormatter is Formatter for CSV (e.g. audit trail for spreadsheets).
+//Each event is a record of time stamp (RFC 3339), level, message (with prefix) and structured fields ("key=value" per cell).
+type CSVFormatter struct{}
+
+var _ FieldFormatter = CSVFormatter{}
+
+//Format is method of Formatter interface.
+func (f CSVFormatter) Format(lv Level, prefix string, t time.Time, msg string) []byte {
+	return f.FormatFields(lv, prefix, t, msg, nil)
+}
+
+//FormatFields is method of FieldFormatter interface.
+func (f CSVFormatter) FormatFields(lv Level, prefix string, t time.Time, msg string, fields []Field) []byte {
+	record := make([]string, 0, 3+len(fields))
+	record = append(record, t.Format(time.RFC3339Nano), lv.String(), prefix+msg)
+	for _, fld := range flattenFields(fields) {
+		record = append(record, fld.Key+"="+logfmtString(fld.Value))
+	}
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	_ = w.Write(record)
+	w.Flush()
+	return buf.Bytes()
+}
+
+//WithCSVHeader returns function for writing header line ("timestamp,level,message,fields")
+//before the first event in CSV format.
+func WithCSVHeader(header bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.csvHeader = header
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,58 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestCSVFormatter(t *testing.T) {
+	tm := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	testCase := []struct {
+		prefix string
+		m      string
+		fields []Field
+		s      string
+	}{
+		{m: "hello", s: "2009-11-10T23:00:00Z,INFO,hello\n"},
+		{prefix: "app: ", m: "hello, world", s: "2009-11-10T23:00:00Z,INFO,\"app: hello, world\"\n"},
+		{m: "say \"hi\"", s: "2009-11-10T23:00:00Z,INFO,\"say \"\"hi\"\"\"\n"},
+		{m: "line1\nline2", s: "2009-11-10T23:00:00Z,INFO,\"line1\nline2\"\n"},
+		{m: "hello", fields: []Field{{Key: "id", Value: 1}, {Key: "tags", Value: []string{"a", "b"}}}, s: "2009-11-10T23:00:00Z,INFO,hello,id=1,\"tags=a,b\"\n"},
+	}
+	for _, tst := range testCase {
+		s := string(CSVFormatter{}.FormatFields(INFO, tst.prefix, tm, tst.m, tst.fields))
+		if s != tst.s {
+			t.Errorf("CSVFormatter.FormatFields()  = \"%v\", want \"%v\".", s, tst.s)
+		}
+	}
+}
+
+func TestWithCSVHeader(t *testing.T) {
+	tm := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFormat(CSV), WithCSVHeader(true), WithClock(func() time.Time { return tm }))
+	l.Print("one")
+	l.Infow("two", Fields{"id": 2})
+	res := "timestamp,level,message,fields\n" +
+		"2009-11-10T23:00:00Z,INFO,one\n" +
+		"2009-11-10T23:00:00Z,INFO,two,id=2\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WithCSVHeader() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,88 @@
+//go:build !logf_release
+// +build !logf_release
+
+package logf
+
+//Tracef calls l.lprintf() to print to the logger.
+func (l *Logger) Tracef(format string, v ...interface{}) { l.lprintf(TRACE, format, v...) }
+
+//Trace calls l.lprint() to print to the logger.
+func (l *Logger) Trace(v ...interface{}) { l.lprint(TRACE, v...) }
+
+//Traceln calls l.lprintln() to print to the logger.
+func (l *Logger) Traceln(v ...interface{}) { l.lprintln(TRACE, v...) }
+
+//Tracet calls l.lprintt() to print to the logger.
+func (l *Logger) Tracet(tmpl string, fields Fields) { l.lprintt(TRACE, tmpl, fields) }
+
+//Debugf calls l.lprintf() to print to the logger.
+func (l *Logger) Debugf(format string, v ...interface{}) { l.lprintf(DEBUG, format, v...) }
+
+//Debug calls l.lprint() to print to the logger.
+func (l *Logger) Debug(v ...interface{}) { l.lprint(DEBUG, v...) }
+
+//Debugln calls l.lprintln() to print to the logger.
+func (l *Logger) Debugln(v ...interface{}) { l.lprintln(DEBUG, v...) }
+
+//Debugt calls l.lprintt() to print to the logger.
+func (l *Logger) Debugt(tmpl string, fields Fields) { l.lprintt(DEBUG, tmpl, fields) }
+
+//Tracef calls std.Tracef() to print to the logger.
+func Tracef(format string, v ...interface{}) { std.lprintf(TRACE, format, v...) }
+
+//Trace calls std.Trace() to print to the logger.
+func Trace(v ...interface{}) { std.lprint(TRACE, v...) }
+
+//Traceln calls std.Traceln() to print to the logger.
+func Traceln(v ...interface{}) { std.lprintln(TRACE, v...) }
+
+//Tracet calls std.Tracet() to print to the logger.
+func Tracet(tmpl string, fields Fields) { std.lprintt(TRACE, tmpl, fields) }
+
+//Debugf calls std.Debugf() to print to the logger.
+func Debugf(format string, v ...interface{}) { std.lprintf(DEBUG, format, v...) }
+
+//Debug calls std.Debug() to print to the logger.
+func Debug(v ...interface{}) { std.lprint(DEBUG, v...) }
+
+//Debugln calls std.Debugln() to print to the logger.
+func Debugln(v ...interface{}) { std.lprintln(DEBUG, v...) }
+
+//Debugt calls std.Debugt() to print to the logger.
+func Debugt(tmpl string, fields Fields) { std.lprintt(DEBUG, tmpl, fields) }
+
+//Trace prints msg at TRACE level with fields of e and releases e.
+func (e *Entry) Trace(msg string) { e.emit(TRACE, msg) }
+
+//Debug prints msg at DEBUG level with fields of e and releases e.
+func (e *Entry) Debug(msg string) { e.emit(DEBUG, msg) }
+
+//Assert prints message at ERROR level if cond is false (and panics if WithAssertPanic option is set).
+//Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) Assert(cond bool, format string, v ...interface{}) {
+	if !cond {
+		l.assertFailed(format, v...)
+	}
+}
+
+//Assert calls std.Assert() to check cond.
+func Assert(cond bool, format string, v ...interface{}) {
+	if !cond {
+		std.assertFailed(format, v...)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,87 @@
+//go:build logf_release
+// +build logf_release
+
+package logf
+
+//Tracef is no-op in release build.
+func (l *Logger) Tracef(format string, v ...interface{}) {}
+
+//Trace is no-op in release build.
+func (l *Logger) Trace(v ...interface{}) {}
+
+//Traceln is no-op in release build.
+func (l *Logger) Traceln(v ...interface{}) {}
+
+//Tracet is no-op in release build.
+func (l *Logger) Tracet(tmpl string, fields Fields) {}
+
+//Debugf is no-op in release build.
+func (l *Logger) Debugf(format string, v ...interface{}) {}
+
+//Debug is no-op in release build.
+func (l *Logger) Debug(v ...interface{}) {}
+
+//Debugln is no-op in release build.
+func (l *Logger) Debugln(v ...interface{}) {}
+
+//Debugt is no-op in release build.
+func (l *Logger) Debugt(tmpl string, fields Fields) {}
+
+//Tracef is no-op in release build.
+func Tracef(format string, v ...interface{}) {}
+
+//Trace is no-op in release build.
+func Trace(v ...interface{}) {}
+
+//Traceln is no-op in release build.
+func Traceln(v ...interface{}) {}
+
+//Tracet is no-op in release build.
+func Tracet(tmpl string, fields Fields) {}
+
+//Debugf is no-op in release build.
+func Debugf(format string, v ...interface{}) {}
+
+//Debug is no-op in release build.
+func Debug(v ...interface{}) {}
+
+//Debugln is no-op in release build.
+func Debugln(v ...interface{}) {}
+
+//Debugt is no-op in release build.
+func Debugt(tmpl string, fields Fields) {}
+
+//Trace is no-op in release build (e is released).
+func (e *Entry) Trace(msg string) {
+	if !e.released() {
+		e.release()
+	}
+}
+
+//Debug is no-op in release build (e is released).
+func (e *Entry) Debug(msg string) {
+	if !e.released() {
+		e.release()
+	}
+}
+
+//Assert is no-op in release build.
+func (l *Logger) Assert(cond bool, format string, v ...interface{}) {}
+
+//Assert is no-op in release build.
+func Assert(cond bool, format string, v ...interface{}) {}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,93 @@
+//go:build logf_release
+// +build logf_release
+
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReleaseTraceDebugOutput(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel),
+		WithMinLevel(TRACE),
+	)
+	l.Tracef("%v", "Tracing")
+	l.Trace("Tracing")
+	l.Traceln("Tracing")
+	l.Debugf("%v", "Debugging")
+	l.Debug("Debugging")
+	l.Debugln("Debugging")
+	l.Print("Information")
+	res := "[INFO] Information\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Logger output = \"%v\", want \"%v\".", s, res)
+	}
+
+	outBuf2 := new(bytes.Buffer)
+	SetFlags(Llevel)
+	SetOutput(outBuf2)
+	SetPrefix("")
+	SetMinLevel(TRACE)
+	Tracef("%v", "Tracing")
+	Trace("Tracing")
+	Traceln("Tracing")
+	Debugf("%v", "Debugging")
+	Debug("Debugging")
+	Debugln("Debugging")
+	if s := outBuf2.String(); s != "" {
+		t.Errorf("Logger output = \"%v\", want \"\".", s)
+	}
+}
+
+func TestEntryTrace(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel))
+	l.Entry().Str("k", "v").Trace("Traceing")
+	l.Entry().Int("n", 1).Debug("Debugging")
+	if s := outBuf.String(); s != "" {
+		t.Errorf("Entry output = \"%v\", want \"\".", s)
+	}
+}
+
+func TestEntryUsedAfterEmit(t *testing.T) {
+	var errs []error
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel), WithErrorHandler(func(err error) { errs = append(errs, err) }))
+	e := l.Entry()
+	e.Info("first")
+	e.Str("k", "v").Info("second")
+	if s := outBuf.String(); s != "[INFO] first\n" {
+		t.Errorf("Entry output = \"%v\", want \"%v\".", s, "[INFO] first\n")
+	}
+	if len(errs) != 2 || errs[0] != ErrEntryReleased {
+		t.Errorf("Entry used after emitting reports %v, want [%v %v].", errs, ErrEntryReleased, ErrEntryReleased)
+	}
+}
+
+func TestReleaseAssert(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel), WithAssertPanic(true))
+	l.Assert(false, "id = %d", 2)
+	if s := outBuf.String(); s != "" {
+		t.Errorf("Assert() = \"%v\", want \"\".", s)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
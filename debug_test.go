@@ -0,0 +1,193 @@
+//go:build !logf_release
+// +build !logf_release
+
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTraceOutput(t *testing.T) {
+	m1 := 123
+	m2 := "string"
+	res := []string{
+		"debug_test.go:29: [TRACE] 123 string\n",
+		"debug_test.go:31: [TRACE] 123string\n",
+		"debug_test.go:33: [TRACE] 123 string\n",
+	}
+	for i, r := range res {
+		outBuf := new(bytes.Buffer)
+		l := New(
+			WithWriter(outBuf),
+			WithFlags(Llevel|Lshortfile),
+			WithPrefix(""),
+			WithMinLevel(TRACE),
+		)
+		switch i {
+		case 0:
+			l.Tracef("%v %v", m1, m2)
+		case 1:
+			l.Trace(m1, m2)
+		default:
+			l.Traceln(m1, m2)
+		}
+		s := outBuf.String()
+		if s != r {
+			t.Errorf("Logger.Trace(%d, \"%s\")  = \"%v\", want \"%v\".", m1, m2, s, r)
+		}
+	}
+	res2 := []string{
+		"debug_test.go:51: [TRACE] 123 string\n",
+		"debug_test.go:53: [TRACE] 123string\n",
+		"debug_test.go:55: [TRACE] 123 string\n",
+	}
+	for i, r := range res2 {
+		outBuf := new(bytes.Buffer)
+		SetFlags(Llevel | Lshortfile)
+		SetOutput(outBuf)
+		switch i {
+		case 0:
+			Tracef("%v %v", m1, m2)
+		case 1:
+			Trace(m1, m2)
+		default:
+			Traceln(m1, m2)
+		}
+		s := outBuf.String()
+		if s != r {
+			t.Errorf("Logger.Trace(%d, \"%s\")  = \"%v\", want \"%v\".", m1, m2, s, r)
+		}
+	}
+}
+
+func TestDebugOutput(t *testing.T) {
+	m1 := 123
+	m2 := "string"
+	res := []string{
+		"debug_test.go:82: [DEBUG] 123 string\n",
+		"debug_test.go:84: [DEBUG] 123string\n",
+		"debug_test.go:86: [DEBUG] 123 string\n",
+	}
+	for i, r := range res {
+		outBuf := new(bytes.Buffer)
+		l := New(
+			WithWriter(outBuf),
+			WithFlags(Llevel|Lshortfile),
+			WithPrefix(""),
+			WithMinLevel(TRACE),
+		)
+		switch i {
+		case 0:
+			l.Debugf("%v %v", m1, m2)
+		case 1:
+			l.Debug(m1, m2)
+		default:
+			l.Debugln(m1, m2)
+		}
+		s := outBuf.String()
+		if s != r {
+			t.Errorf("Logger.Debug(%d, \"%s\")  = \"%v\", want \"%v\".", m1, m2, s, r)
+		}
+	}
+	res2 := []string{
+		"debug_test.go:104: [DEBUG] 123 string\n",
+		"debug_test.go:106: [DEBUG] 123string\n",
+		"debug_test.go:108: [DEBUG] 123 string\n",
+	}
+	for i, r := range res2 {
+		outBuf := new(bytes.Buffer)
+		SetFlags(Llevel | Lshortfile)
+		SetOutput(outBuf)
+		switch i {
+		case 0:
+			Debugf("%v %v", m1, m2)
+		case 1:
+			Debug(m1, m2)
+		default:
+			Debugln(m1, m2)
+		}
+		s := outBuf.String()
+		if s != r {
+			t.Errorf("Logger.Debug(%d, \"%s\")  = \"%v\", want \"%v\".", m1, m2, s, r)
+		}
+	}
+}
+
+func TestEntryTrace(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel))
+	l.Entry().Str("k", "v").Trace("Traceing")
+	l.Entry().Int("n", 1).Debug("Debugging")
+	res := "[TRACE] Traceing k=v\n[DEBUG] Debugging n=1\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Entry output = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestEntryUsedAfterEmit(t *testing.T) {
+	var errs []error
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel), WithErrorHandler(func(err error) { errs = append(errs, err) }))
+	e := l.Entry()
+	e.Info("first")
+	e.Str("k", "v").Info("second")
+	if s := outBuf.String(); s != "[INFO] first\n" {
+		t.Errorf("Entry output = \"%v\", want \"%v\".", s, "[INFO] first\n")
+	}
+	if len(errs) != 2 || errs[0] != ErrEntryReleased {
+		t.Errorf("Entry used after emitting reports %v, want [%v %v].", errs, ErrEntryReleased, ErrEntryReleased)
+	}
+}
+
+func TestEntryUsedAfterEmitTestMode(t *testing.T) {
+	SetTestMode(true)
+	defer SetTestMode(false)
+	l := New(WithWriter(new(bytes.Buffer)))
+	e := l.Entry()
+	e.Info("first")
+	defer func() {
+		if r := recover(); r != ErrEntryReleased {
+			t.Errorf("Entry used after emitting panics with \"%v\", want \"%v\".", r, ErrEntryReleased)
+		}
+	}()
+	e.Str("k", "v")
+}
+
+func TestAssert(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel|Lshortfile))
+	l.Assert(true, "id = %d", 1)
+	l.Assert(false, "id = %d", 2)
+	res := "debug_test.go:161: [ERROR] id = 2\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Assert() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestWithAssertPanic(t *testing.T) {
+	l := New(WithWriter(new(bytes.Buffer)), WithAssertPanic(true))
+	l.Assert(true, "ok")
+	defer func() {
+		if r := recover(); r != "id = 2" {
+			t.Errorf("Assert() panics with \"%v\", want \"%v\".", r, "id = 2")
+		}
+	}()
+	l.Assert(false, "id = %d", 2)
+	t.Error("Assert() does not panic.")
+}
+
+/* Copyright 2018,2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,108 @@
+package logf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+//WithStartupBanner returns function for printing summary of configuration (by Describe method)
+//at INFO level when the logger is created by New function.
+func WithStartupBanner(banner bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.banner = banner
+	}
+}
+
+//printBanner prints startup banner if WithStartupBanner option is set.
+//calldepth is the same as l.Output().
+func (l *Logger) printBanner(calldepth int) {
+	l.mu.Lock()
+	banner := l.banner
+	l.mu.Unlock()
+	if banner {
+		_ = l.Output(INFO, calldepth+1, "logger started: "+l.Describe())
+	}
+}
+
+//Describe returns human-readable summary of configuration of the logger
+//(minimum level, flags, format, writers, hooks and rate limits) for troubleshooting.
+func (l *Logger) Describe() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	items := []string{
+		"min_level=" + l.minLevel().String(),
+		"flags=" + describeFlags(l.flags()),
+		"format=" + describeFormatter(l.formatter),
+		fmt.Sprintf("output=%T", l.lg.Writer()),
+		fmt.Sprintf("sinks=%d", len(l.sinks)),
+		fmt.Sprintf("routes=%d", len(l.routes)),
+		fmt.Sprintf("tees=%d", len(l.tees)),
+		fmt.Sprintf("hooks=%d", len(l.hooks)),
+		fmt.Sprintf("fatal_hooks=%d", len(l.fatalHooks)),
+		fmt.Sprintf("fields=%d", l.fields.len()),
+	}
+	if s := l.sampler; s != nil {
+		items = append(items, fmt.Sprintf("sampling=%v(first=%d,thereafter=%d)", s.lv, s.first, s.thereafter))
+	}
+	for _, lv := range l.reservoirLevels() {
+		r := l.reservoirs[lv]
+		items = append(items, fmt.Sprintf("reservoir=%v(k=%d,window=%v)", lv, r.k, r.window))
+	}
+	if b := l.bursts; b != nil {
+		items = append(items, fmt.Sprintf("burst=%d/s", b.limit))
+	}
+	items = append(items, fmt.Sprintf("filtered=%d", atomic.LoadUint64(&l.filtered)))
+	return strings.Join(items, " ")
+}
+
+//describeFlags returns names of flags joined by "|" ("none" if no flags).
+func describeFlags(flag int) string {
+	names := []string{}
+	for name, f := range configFlags {
+		if f != LstdFlags && (flag&f) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+	return strings.Join(names, "|")
+}
+
+//describeFormatter returns name of formatter.
+func describeFormatter(f Formatter) string {
+	switch f.(type) {
+	case nil:
+		return TEXT.String()
+	case JSONFormatter:
+		return JSON.String()
+	case LogfmtFormatter:
+		return LOGFMT.String()
+	case GELFFormatter:
+		return GELF.String()
+	case CSVFormatter:
+		return CSV.String()
+	default:
+		return fmt.Sprintf("%T", f)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
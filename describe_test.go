@@ -0,0 +1,60 @@
+package logf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	l := New(
+		WithWriter(&bytes.Buffer{}),
+		WithFlags(Ltime|Llevel),
+		WithMinLevel(INFO),
+		WithFormat(JSON),
+		WithSink(TEXT, &bytes.Buffer{}),
+		WithFatalHook(func(string) {}),
+		WithSampleFirstN(DEBUG, 10, 100),
+		WithBurstSummary(5),
+	)
+	l.Output(DEBUG, 1, "filtered")
+	res := "min_level=INFO flags=level|time format=json output=*bytes.Buffer sinks=1 routes=0 tees=0 hooks=0 fatal_hooks=1 fields=0 sampling=DEBUG(first=10,thereafter=100) burst=5/s filtered=1"
+	if s := l.Describe(); s != res {
+		t.Errorf("Describe() = \"%v\", want \"%v\".", s, res)
+	}
+	res = "min_level=TRACE flags=none format=text output=*bytes.Buffer sinks=0 routes=0 tees=0 hooks=0 fatal_hooks=0 fields=1 filtered=0"
+	if s := New(WithWriter(&bytes.Buffer{}), WithFlags(0)).WithFields(Fields{"id": 1}).Describe(); s != res {
+		t.Errorf("Describe() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestWithStartupBanner(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel|Lshortfile), WithFormat(LOGFMT), WithStartupBanner(true))
+	l.Print("hello")
+	res := `level=INFO msg="logger started: min_level=TRACE flags=level|shortfile format=logfmt output=*bytes.Buffer sinks=0 routes=0 tees=0 hooks=0 fatal_hooks=0 fields=0 filtered=0" caller=describe_test.go:33`
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], res) || !strings.Contains(lines[1], `msg=hello`) {
+		t.Errorf("WithStartupBanner() = \"%v\", want banner \"%v\".", buf.String(), res)
+	}
+	buf.Reset()
+	New(WithWriter(buf), WithMinLevel(WARN), WithStartupBanner(true))
+	if buf.Len() != 0 {
+		t.Errorf("WithStartupBanner() = \"%v\", want filtered.", buf.String())
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,124 @@
+package logf
+
+import "sync"
+
+//Entry is builder of logging event with structured fields.
+//Entry is pooled: emitting methods (Info, Error, ...) release it, so it must not be used after that.
+//Misuse is reported to error handler of the logger (ErrEntryReleased), and panics in test mode (SetTestMode function).
+type Entry struct {
+	l      *Logger // nil if released
+	last   *Logger // logger before release (for reporting misuse)
+	fields []Field
+}
+
+var entryPool = sync.Pool{
+	New: func() interface{} { return &Entry{fields: make([]Field, 0, 8)} },
+}
+
+//Entry returns Entry of the logger from pool.
+func (l *Logger) Entry() *Entry {
+	e := entryPool.Get().(*Entry)
+	e.l = l
+	e.last = nil
+	return e
+}
+
+//NewEntry returns Entry of std from pool.
+func NewEntry() *Entry {
+	return std.Entry()
+}
+
+//Str adds structured field of string value.
+func (e *Entry) Str(key, val string) *Entry { return e.add(key, val) }
+
+//Int adds structured field of integer value.
+func (e *Entry) Int(key string, val int) *Entry { return e.add(key, val) }
+
+//Any adds structured field of any value.
+func (e *Entry) Any(key string, val interface{}) *Entry { return e.add(key, val) }
+
+//Err adds structured field of error ("error" key). It is ignored if err is nil.
+func (e *Entry) Err(err error) *Entry {
+	if err == nil {
+		return e
+	}
+	return e.add("error", err)
+}
+
+//Info prints msg at INFO level with fields of e and releases e.
+func (e *Entry) Info(msg string) { e.emit(INFO, msg) }
+
+//Warn prints msg at WARN level with fields of e and releases e.
+func (e *Entry) Warn(msg string) { e.emit(WARN, msg) }
+
+//Error prints msg at ERROR level with fields of e and releases e.
+func (e *Entry) Error(msg string) { e.emit(ERROR, msg) }
+
+//Fatal prints msg at FATAL level with fields of e, releases e and calls fatal hooks and exit function.
+func (e *Entry) Fatal(msg string) {
+	l := e.l
+	e.emit(FATAL, msg)
+	if l != nil {
+		l.fatal(trimNewline(msg))
+	}
+}
+
+//add appends structured field.
+func (e *Entry) add(key string, val interface{}) *Entry {
+	if e.released() {
+		return e
+	}
+	e.fields = append(e.fields, Field{Key: key, Value: val})
+	return e
+}
+
+//emit writes a logging event and releases e.
+func (e *Entry) emit(lv Level, msg string) {
+	if e.released() {
+		return
+	}
+	_ = e.l.output(lv, e.l.depth, trimNewline(msg), e.fields)
+	e.release()
+}
+
+//released reports whether e is released. Misuse is reported to error handler, or panics in test mode.
+func (e *Entry) released() bool {
+	if e.l != nil {
+		return false
+	}
+	if inTestMode() {
+		panic(ErrEntryReleased)
+	}
+	l := e.last
+	if l == nil {
+		l = std
+	}
+	l.handleError(ErrEntryReleased)
+	return true
+}
+
+//release resets e and puts it back to pool.
+func (e *Entry) release() {
+	for i := range e.fields {
+		e.fields[i] = Field{}
+	}
+	e.last = e.l
+	e.l = nil
+	e.fields = e.fields[:0]
+	entryPool.Put(e)
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,112 @@
+package logf
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestEntry(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel|Lshortfile))
+	l.Entry().Str("user", "alice").Int("id", 1).Err(errors.New("denied")).Error("login failed")
+	l.Entry().Any("ok", true).Err(nil).Info("login")
+	l.Entry().Warn("no fields")
+	res := "entry_test.go:14: [ERROR] login failed user=alice id=1 error=denied\n" +
+		"entry_test.go:15: [INFO] login ok=true\n" +
+		"entry_test.go:16: [WARN] no fields\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Entry output = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestEntryReset(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel))
+	for i := 0; i < 100; i++ {
+		outBuf.Reset()
+		e := l.Entry()
+		if e.l != l || len(e.fields) != 0 {
+			t.Fatalf("Entry is not reset: %+v", e)
+		}
+		e.Int("i", i).Info("loop")
+		if s, res := outBuf.String(), "[INFO] loop i="+itoa(i)+"\n"; s != res {
+			t.Errorf("Entry output = \"%v\", want \"%v\".", s, res)
+		}
+	}
+}
+
+func itoa(i int) string {
+	return sprint(i)
+}
+
+//nopFormatter is FieldFormatter which discards events (for measuring cost of fields).
+type nopFormatter struct{}
+
+var nopLine = []byte("\n")
+
+func (f nopFormatter) Format(lv Level, prefix string, t time.Time, msg string) []byte { return nopLine }
+
+func (f nopFormatter) FormatFields(lv Level, prefix string, t time.Time, msg string, fields []Field) []byte {
+	return nopLine
+}
+
+func BenchmarkEntry(b *testing.B) {
+	l := New(WithWriter(ioutil.Discard), WithFormatter(nopFormatter{}))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Entry().Str("user", "alice").Int("id", i).Info("login")
+	}
+}
+
+func BenchmarkInfow(b *testing.B) {
+	l := New(WithWriter(ioutil.Discard), WithFormatter(nopFormatter{}))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Infow("login", Fields{"user": "alice", "id": i})
+	}
+}
+
+const chainDepth = 16
+
+func BenchmarkWithFieldsChain(b *testing.B) {
+	l := New(WithWriter(ioutil.Discard), WithFormatter(nopFormatter{}))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		child := l
+		for j := 0; j < chainDepth; j++ {
+			child = child.Bool("ok", true)
+		}
+		child.Print("login")
+	}
+}
+
+//BenchmarkWithFieldsCopy is baseline of BenchmarkWithFieldsChain (fields are copied at each child logger).
+func BenchmarkWithFieldsCopy(b *testing.B) {
+	l := New(WithWriter(ioutil.Discard), WithFormatter(nopFormatter{}))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var fields []Field
+		for j := 0; j < chainDepth; j++ {
+			fields = append(append([]Field{}, fields...), Field{Key: "ok", Value: true})
+		}
+		_ = l.output(INFO, 2, "login", fields)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,85 @@
+package logf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrUTCWithoutTime is reported when LUTC flag is set without Ldate or Ltime flag.
+var ErrUTCWithoutTime = errors.New("LUTC flag has no effect without Ldate or Ltime flag")
+
+//ErrTeeLoop is reported when Tee method would make a loop of loggers.
+var ErrTeeLoop = errors.New("tee to the logger itself is ignored")
+
+//ErrCapturePanic is returned by Capture method when the function panics.
+var ErrCapturePanic = errors.New("panic in captured function")
+
+//ErrNotReopenable is returned by Reopen method when the output is not a file opened by WithOutputFile option.
+var ErrNotReopenable = errors.New("output is not a file opened by WithOutputFile option")
+
+//ErrOpenOutputFile is reported when the file of WithOutputFile option cannot be opened.
+var ErrOpenOutputFile = errors.New("failed to open output file")
+
+//ErrEntryReleased is reported when Entry is used after emitting.
+var ErrEntryReleased = errors.New("Entry is used after emitting")
+
+//ErrWriteFailed is reported when a logging event cannot be written to the output.
+var ErrWriteFailed = errors.New("failed to write logging event")
+
+//WithDiagnosticsOutput returns function for setting writer of internal errors and warnings
+//(os.Stderr by default). They are not written if w is nil or handler is set by WithErrorHandler option.
+func WithDiagnosticsOutput(w io.Writer) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.diag = w
+	}
+}
+
+//WithErrorHandler returns function for setting handler of internal errors and warnings.
+func WithErrorHandler(h func(error)) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.errHandler = h
+	}
+}
+
+//handleError calls error handler (if set) with err, or writes err to diagnostics output.
+func (l *Logger) handleError(err error) {
+	if err == nil {
+		return
+	}
+	l.mu.Lock()
+	h, w := l.errHandler, l.diag
+	l.mu.Unlock()
+	switch {
+	case h != nil:
+		h(err)
+	case w != nil:
+		_, _ = fmt.Fprintf(w, "logf: %v\n", err)
+	}
+}
+
+//checkFlags reports inconsistent flags to error handler.
+func (l *Logger) checkFlags() {
+	if flag := l.Flags(); (flag&LUTC) != 0 && (flag&(Ldate|Ltime)) == 0 {
+		l.handleError(ErrUTCWithoutTime)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,99 @@
+package logf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var errBrokenWriter = errors.New("broken writer")
+
+type brokenWriter struct{}
+
+func (w brokenWriter) Write(p []byte) (int, error) { return 0, errBrokenWriter }
+
+func TestWithDiagnosticsOutput(t *testing.T) {
+	diag := &bytes.Buffer{}
+	l := New(WithWriter(brokenWriter{}), WithDiagnosticsOutput(diag))
+	if err := l.Output(INFO, 1, "hello"); !errors.Is(err, errBrokenWriter) {
+		t.Errorf("Output() = \"%v\", want \"%v\".", err, errBrokenWriter)
+	}
+	if s := diag.String(); !strings.HasPrefix(s, "logf: "+ErrWriteFailed.Error()) || !strings.Contains(s, errBrokenWriter.Error()) {
+		t.Errorf("diagnostics = \"%v\", want write failure.", s)
+	}
+
+	diag.Reset()
+	var got error
+	l = New(WithWriter(brokenWriter{}), WithDiagnosticsOutput(diag), WithErrorHandler(func(err error) { got = err }))
+	l.Print("hello")
+	if !errors.Is(got, ErrWriteFailed) {
+		t.Errorf("error handler = \"%v\", want \"%v\".", got, ErrWriteFailed)
+	}
+	if diag.Len() != 0 {
+		t.Errorf("diagnostics = \"%v\", want empty.", diag.String())
+	}
+}
+
+type stackError struct{ msg string }
+
+func (e stackError) Error() string { return e.msg }
+
+func (e stackError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprintf(s, "%s\nmain.main\n\t/app/main.go:10", e.msg)
+		return
+	}
+	fmt.Fprint(s, e.msg)
+}
+
+func TestWithVerboseErrors(t *testing.T) {
+	testCase := []struct {
+		err error
+		s   string
+	}{
+		{err: stackError{msg: "failed"}, s: "[ERROR] hello error=failed error_verbose=\"failed\\nmain.main\\n\\t/app/main.go:10\"\n"},
+		{err: errors.New("failed"), s: "[ERROR] hello error=failed\n"},
+	}
+	for _, tst := range testCase {
+		buf := new(bytes.Buffer)
+		l := New(WithWriter(buf), WithFlags(Llevel), WithVerboseErrors(true))
+		l.ErrorWithErr("hello", tst.err)
+		if s := buf.String(); s != tst.s {
+			t.Errorf("ErrorWithErr() = \"%v\", want \"%v\".", s, tst.s)
+		}
+	}
+	buf := new(bytes.Buffer)
+	New(WithWriter(buf), WithFlags(Llevel)).ErrorWithErr("hello", stackError{msg: "failed"})
+	if s := buf.String(); s != "[ERROR] hello error=failed\n" {
+		t.Errorf("ErrorWithErr() = \"%v\", want \"%v\".", s, "[ERROR] hello error=failed\n")
+	}
+}
+
+func TestWithVerboseErrorsEntry(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel), WithVerboseErrors(true), WithErrorType(true))
+	l.Entry().Err(stackError{msg: "failed"}).Error("hello")
+	l.WithFields(Fields{"cause": stackError{msg: "broken"}}).Warn("world")
+	res := "[ERROR] hello error=failed error_type=logf.stackError error_verbose=\"failed\\nmain.main\\n\\t/app/main.go:10\"\n" +
+		"[WARN] world cause=broken cause_type=logf.stackError cause_verbose=\"broken\\nmain.main\\n\\t/app/main.go:10\"\n"
+	if s := buf.String(); s != res {
+		t.Errorf("Entry.Err() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,79 @@
+//go:build windows
+// +build windows
+
+package logf
+
+import (
+	"strings"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+//eventID is event ID of Windows event log entries.
+const eventID = 1
+
+//eventLogWriter is LevelWriter for Windows event log.
+type eventLogWriter struct {
+	el *eventlog.Log
+}
+
+var _ LevelWriter = (*eventLogWriter)(nil)
+
+//Write is method of io.Writer interface (as INFO level).
+func (w *eventLogWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(INFO, p)
+}
+
+//WriteLevel is method of LevelWriter interface.
+//ERROR and FATAL are Error events, WARN is Warning event, and others are Information events.
+func (w *eventLogWriter) WriteLevel(lv Level, p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	var err error
+	switch {
+	case lv.GTE(ERROR):
+		err = w.el.Error(eventID, msg)
+	case lv == WARN:
+		err = w.el.Warning(eventID, msg)
+	default:
+		err = w.el.Info(eventID, msg)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+//Close closes the event log.
+func (w *eventLogWriter) Close() error {
+	return w.el.Close()
+}
+
+//WithEventLog returns function for writing to Windows event log of source.
+//Date and time flags are cleared because event log records time by itself.
+//The source must be registered in advance (e.g. eventlog.InstallAsEventCreate()).
+func WithEventLog(source string) (OptFunc, error) {
+	el, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	w := &eventLogWriter{el: el}
+	return func(l *Logger) {
+		l.SetOutput(w)
+		l.SetFlags(l.Flags() &^ (Ldate | Ltime | Lmicroseconds))
+	}, nil
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,54 @@
+//go:build windows
+// +build windows
+
+package logf
+
+import (
+	"testing"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+func TestEventLog(t *testing.T) {
+	const source = "logf-test"
+	if err := eventlog.InstallAsEventCreate(source, eventlog.Error|eventlog.Warning|eventlog.Info); err != nil {
+		t.Skipf("cannot register event source (administrator privileges required): %v", err)
+	}
+	defer func() { _ = eventlog.Remove(source) }()
+
+	opt, err := WithEventLog(source)
+	if err != nil {
+		t.Fatalf("WithEventLog() = \"%v\", want nil.", err)
+	}
+	l := New(opt, WithMinLevel(TRACE))
+	if (l.Flags() & (Ldate | Ltime)) != 0 {
+		t.Errorf("Logger.Flags()  = %#x, want without time flags.", l.Flags())
+	}
+	for _, lv := range []Level{TRACE, DEBUG, INFO, WARN, ERROR, FATAL} {
+		if err := l.Output(lv, 2, "logf test: "+lv.String()); err != nil {
+			t.Errorf("Logger.Output(%v) = \"%v\", want nil.", lv, err)
+		}
+	}
+	if w, ok := l.GetLogger().Writer().(*eventLogWriter); ok {
+		if err := w.Close(); err != nil {
+			t.Errorf("Close() = \"%v\", want nil.", err)
+		}
+	} else {
+		t.Error("output of logger is not event log")
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
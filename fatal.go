@@ -0,0 +1,65 @@
+package logf
+
+//WithFatalHook returns function for adding hook called by Fatal* functions.
+//Hooks are called in registration order after writing the fatal message and before exit
+//(buffered output and pending events are flushed between hooks and exit).
+func WithFatalHook(hook func(msg string)) OptFunc {
+	return func(l *Logger) {
+		if hook == nil {
+			return
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.fatalHooks = append(l.fatalHooks, hook)
+	}
+}
+
+//WithExitFunc returns function for setting exit function called by Fatal* functions
+//(default os.Exit). The exit function is called after fatal hooks. If exit is nil, Fatal* functions do not exit.
+func WithExitFunc(exit func(code int)) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.exit = exit
+	}
+}
+
+//WithFatalNoExit returns function for logging at FATAL level without exit.
+//It is useful for libraries which must not terminate the process.
+func WithFatalNoExit() OptFunc {
+	return WithExitFunc(nil)
+}
+
+//fatal calls fatal hooks and exit function (buffered output is flushed before exit).
+func (l *Logger) fatal(msg string) {
+	l.mu.Lock()
+	hooks := append([]func(string){}, l.fatalHooks...)
+	exit := l.exit
+	l.mu.Unlock()
+	for _, hook := range hooks {
+		hook(msg)
+	}
+	if exit == nil {
+		return
+	}
+	_ = l.flush()
+	if inTestMode() {
+		panic(ExitPanic{Code: 1, Msg: msg})
+	}
+	exit(1)
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
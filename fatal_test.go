@@ -0,0 +1,120 @@
+package logf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFatalHook(t *testing.T) {
+	m1 := 123
+	m2 := "string"
+	for i := 0; i < 4; i++ {
+		outBuf := new(bytes.Buffer)
+		calls := []string{}
+		l := New(
+			WithWriter(outBuf),
+			WithFlags(Llevel),
+			WithFatalHook(func(msg string) { calls = append(calls, "hook1: "+msg) }),
+			WithFatalHook(func(msg string) { calls = append(calls, fmt.Sprintf("hook2: written=%v", outBuf.Len() > 0)) }),
+			WithExitFunc(func(code int) { calls = append(calls, fmt.Sprintf("exit(%d)", code)) }),
+		)
+		res := []string{"hook1: 123 string", "hook2: written=true", "exit(1)"}
+		switch i {
+		case 0:
+			l.Fatalf("%v %v", m1, m2)
+		case 1:
+			l.Fatal(m1, " ", m2)
+		case 2:
+			l.Fatalln(m1, m2)
+		default:
+			l.Fatalt("{m1} {m2}", Fields{"m1": m1, "m2": m2})
+		}
+		if !reflect.DeepEqual(calls, res) {
+			t.Errorf("calls of Logger.Fatal(%d) = %v, want %v.", i, calls, res)
+		}
+		if s := outBuf.String(); s != "[FATAL] 123 string\n" {
+			t.Errorf("Logger.Fatal(%d) = \"%v\", want \"%v\".", i, s, "[FATAL] 123 string\n")
+		}
+	}
+}
+
+func TestFatalHookFiltered(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	calls := []string{}
+	l := New(
+		WithWriter(outBuf),
+		WithMinLevel(FATAL+1),
+		WithFatalNoExit(),
+		WithFatalHook(func(msg string) { calls = append(calls, msg) }),
+	)
+	l.Fatal("Fatal Erroring")
+	if outBuf.Len() != 0 {
+		t.Errorf("Logger.Fatal() = \"%v\", want \"\".", outBuf.String())
+	}
+	if !reflect.DeepEqual(calls, []string{"Fatal Erroring"}) {
+		t.Errorf("calls of Logger.Fatal() = %v, want %v.", calls, []string{"Fatal Erroring"})
+	}
+}
+
+func TestFatalNoExit(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	exited := false
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel),
+		WithExitFunc(func(code int) { exited = true }),
+		WithFatalNoExit(),
+	)
+	l.Fatal("Fatal Erroring")
+	if exited {
+		t.Error("Logger.Fatal() exited with WithFatalNoExit option.")
+	}
+	if s := outBuf.String(); s != "[FATAL] Fatal Erroring\n" {
+		t.Errorf("Logger.Fatal() = \"%v\", want \"%v\".", s, "[FATAL] Fatal Erroring\n")
+	}
+}
+
+func TestFatalFlush(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	outBuf := new(bytes.Buffer)
+	w := bufio.NewWriter(outBuf)
+	written := ""
+	l := New(
+		WithWriter(w),
+		WithFlags(Llevel),
+		WithClock(func() time.Time { return now }),
+		WithBurstSummary(1),
+		WithExitFunc(func(code int) { written = outBuf.String() }),
+	)
+	l.Print("first")
+	l.Fatal("Fatal Erroring")
+	res := "[INFO] first\n[FATAL] Fatal Erroring\n[WARN] 2 messages in last second\n"
+	if written != res {
+		t.Errorf("output before exit of Logger.Fatal() = \"%v\", want \"%v\".", written, res)
+	}
+}
+
+func TestFatalExitByDefault(t *testing.T) {
+	if New().exit == nil || std.exit == nil {
+		t.Error("Fatal* functions do not exit by default.")
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,492 @@
+package logf
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//lazyValue is value of structured field evaluated at emitting.
+type lazyValue func() interface{}
+
+//fieldChain is immutable chain of structured fields of child loggers.
+//Fields of parent logger are shared (not copied) and flattened at emitting.
+type fieldChain struct {
+	parent *fieldChain
+	fields []Field
+	n      int // number of fields in the chain (including parents)
+}
+
+//push returns new chain of fields following c (c itself if fields is empty).
+func (c *fieldChain) push(fields []Field) *fieldChain {
+	if len(fields) == 0 {
+		return c
+	}
+	return &fieldChain{parent: c, fields: fields, n: c.len() + len(fields)}
+}
+
+//len returns number of fields in the chain.
+func (c *fieldChain) len() int {
+	if c == nil {
+		return 0
+	}
+	return c.n
+}
+
+//appendTo appends fields in the chain (fields of root first) to dst.
+func (c *fieldChain) appendTo(dst []Field) []Field {
+	if c == nil {
+		return dst
+	}
+	return append(c.parent.appendTo(dst), c.fields...)
+}
+
+//list returns flattened fields in the chain (nil if empty).
+func (c *fieldChain) list() []Field {
+	if c.len() == 0 {
+		return nil
+	}
+	return c.appendTo(make([]Field, 0, c.n))
+}
+
+//levelValue is value of structured field emitted only at its level or more verbose levels.
+type levelValue struct {
+	lv  Level
+	val interface{}
+}
+
+//Err returns structured field of error (conventional "error" key).
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+//WithErrorType returns function for emitting type name of error as "error_type" field
+//(for each field of error value; the key is suffixed by "_type").
+func WithErrorType(flag bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.errType = flag
+	}
+}
+
+//WithVerboseErrors returns function for emitting "%+v" rendering of error (e.g. with stack trace of pkg/errors)
+//as "error_verbose" field (for each field of error value; the key is suffixed by "_verbose").
+//The field is omitted if it is the same as the error message.
+func WithVerboseErrors(flag bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.verboseErr = flag
+	}
+}
+
+//errFields returns structured field of error (nil if err is nil).
+func errFields(err error) []Field {
+	if err == nil {
+		return nil
+	}
+	return []Field{Err(err)}
+}
+
+//expandErrors returns fields with type name ("_type" suffix of key) and "%+v" rendering ("_verbose" suffix of key)
+//following each field of error value, as set by WithErrorType and WithVerboseErrors options.
+func (l *Logger) expandErrors(fields []Field) []Field {
+	l.mu.Lock()
+	typ, verbose := l.errType, l.verboseErr
+	l.mu.Unlock()
+	if !typ && !verbose {
+		return fields
+	}
+	var res []Field
+	for i, fld := range fields {
+		err, ok := fld.Value.(error)
+		if !ok {
+			if res != nil {
+				res = append(res, fld)
+			}
+			continue
+		}
+		if res == nil {
+			res = append(make([]Field, 0, len(fields)+2), fields[:i]...)
+		}
+		res = append(res, fld)
+		if typ {
+			res = append(res, Field{Key: fld.Key + "_type", Value: fmt.Sprintf("%T", err)})
+		}
+		if verbose {
+			if v := safeString(func() string { return fmt.Sprintf("%+v", err) }); v != safeString(err.Error) {
+				res = append(res, Field{Key: fld.Key + "_verbose", Value: v})
+			}
+		}
+	}
+	if res == nil {
+		return fields
+	}
+	return res
+}
+
+//ErrorWithErr prints msg at ERROR level with err as structured field ("error" key).
+func (l *Logger) ErrorWithErr(msg string, err error) {
+	_ = l.output(ERROR, l.depth-1, trimNewline(msg), errFields(err))
+}
+
+//ErrorWithErr calls std.ErrorWithErr() to print to the logger.
+func ErrorWithErr(msg string, err error) {
+	_ = std.output(ERROR, std.depth-1, trimNewline(msg), errFields(err))
+}
+
+//OutputFields writes the output for a logging event with structured fields (sorted by key).
+//calldepth is the same as Output method.
+func (l *Logger) OutputFields(lv Level, calldepth int, msg string, fields map[string]interface{}) error {
+	return l.output(lv, calldepth+1, msg, sortedFields(fields))
+}
+
+//Infow prints msg at INFO level with structured fields.
+func (l *Logger) Infow(msg string, fields Fields) {
+	_ = l.output(INFO, l.depth-1, trimNewline(msg), sortedFields(fields))
+}
+
+//Warnw prints msg at WARN level with structured fields.
+func (l *Logger) Warnw(msg string, fields Fields) {
+	_ = l.output(WARN, l.depth-1, trimNewline(msg), sortedFields(fields))
+}
+
+//Errorw prints msg at ERROR level with structured fields.
+func (l *Logger) Errorw(msg string, fields Fields) {
+	_ = l.output(ERROR, l.depth-1, trimNewline(msg), sortedFields(fields))
+}
+
+//OutputFields calls std.OutputFields() to print to the logger.
+func OutputFields(lv Level, calldepth int, msg string, fields map[string]interface{}) error {
+	return std.output(lv, calldepth+1, msg, sortedFields(fields))
+}
+
+//Infow calls std.Infow() to print to the logger.
+func Infow(msg string, fields Fields) {
+	_ = std.output(INFO, std.depth-1, trimNewline(msg), sortedFields(fields))
+}
+
+//Warnw calls std.Warnw() to print to the logger.
+func Warnw(msg string, fields Fields) {
+	_ = std.output(WARN, std.depth-1, trimNewline(msg), sortedFields(fields))
+}
+
+//Errorw calls std.Errorw() to print to the logger.
+func Errorw(msg string, fields Fields) {
+	_ = std.output(ERROR, std.depth-1, trimNewline(msg), sortedFields(fields))
+}
+
+//WithFields returns child logger with structured fields.
+//The child logger shares configuration and output with l.
+func (l *Logger) WithFields(fields Fields) *Logger {
+	return l.with(sortedFields(fields)...)
+}
+
+//sortedFields returns structured fields sorted by key.
+func sortedFields(fields Fields) []Field {
+	if len(fields) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	flds := make([]Field, 0, len(keys))
+	for _, k := range keys {
+		flds = append(flds, Field{Key: k, Value: fields[k]})
+	}
+	return flds
+}
+
+//WithLazyField returns child logger with structured field evaluated lazily.
+//fn is called only if a logging event is emitted (passes level filter).
+func (l *Logger) WithLazyField(key string, fn func() interface{}) *Logger {
+	return l.with(Field{Key: key, Value: lazyValue(fn)})
+}
+
+//Dur returns child logger with duration field.
+//The duration is rendered as number of milliseconds (e.g. 1.5 for 1500µs).
+func (l *Logger) Dur(key string, d time.Duration) *Logger {
+	return l.with(Field{Key: key, Value: float64(d) / float64(time.Millisecond)})
+}
+
+//Time returns child logger with time field.
+//The time is rendered in RFC 3339 format (with fractional seconds if any).
+func (l *Logger) Time(key string, t time.Time) *Logger {
+	return l.with(Field{Key: key, Value: t.Format(time.RFC3339Nano)})
+}
+
+//WithTTL returns child logger with "ttl" field for retention policy of log store.
+//The duration is rendered as number of seconds (e.g. 86400 for 24h).
+func (l *Logger) WithTTL(d time.Duration) *Logger {
+	return l.with(Field{Key: "ttl", Value: d.Seconds()})
+}
+
+//WithFieldsAtLevel returns child logger with structured fields emitted only at level lv or more verbose levels
+//(e.g. fields at DEBUG level appear in DEBUG and TRACE events, but not in INFO events).
+func (l *Logger) WithFieldsAtLevel(lv Level, fields Fields) *Logger {
+	flds := sortedFields(fields)
+	for i := range flds {
+		flds[i].Value = levelValue{lv: lv, val: flds[i].Value}
+	}
+	return l.with(flds...)
+}
+
+//Bool returns child logger with boolean field (rendered as true or false).
+func (l *Logger) Bool(key string, b bool) *Logger {
+	return l.with(Field{Key: key, Value: b})
+}
+
+//Float returns child logger with floating-point field.
+//The number is rendered in the shortest representation which round-trips (e.g. 0.25, 1e+21).
+func (l *Logger) Float(key string, f float64) *Logger {
+	return l.with(Field{Key: key, Value: f})
+}
+
+//Uint returns child logger with unsigned integer field.
+func (l *Logger) Uint(key string, u uint64) *Logger {
+	return l.with(Field{Key: key, Value: u})
+}
+
+//eventFields returns fields of the logger for event at level lv,
+//processed the same way as fields of Output (level, lazy, group, transformer and cap).
+func (l *Logger) eventFields(lv Level) []Field {
+	return l.capFields(l.transformFields(groupFields(l.expandErrors(resolveFields(levelFields(lv, l.fields.list()))))))
+}
+
+//with returns child logger with structured fields appended.
+func (l *Logger) with(fields ...Field) *Logger {
+	return &Logger{core: l.core, fields: l.fields.push(l.prefixKeys(fields)), reqLevel: l.reqLevel, keyPrefix: l.keyPrefix, group: l.group, tees: l.teeLoggers()}
+}
+
+//WithFieldPrefix returns child logger which prefixes keys of structured fields added by it (e.g. "db." for "db.query").
+//Fields of parent logger and the message are not changed. Prefixes of nested child loggers are concatenated.
+func (l *Logger) WithFieldPrefix(prefix string) *Logger {
+	child := l.with()
+	child.keyPrefix += prefix
+	return child
+}
+
+//prefixKeys returns copy of fields with keys prefixed by WithFieldPrefix method and groups of WithGroup method.
+func (l *Logger) prefixKeys(fields []Field) []Field {
+	prefix := l.group + l.keyPrefix
+	if len(prefix) == 0 || len(fields) == 0 {
+		return fields
+	}
+	flds := make([]Field, len(fields))
+	for i, fld := range fields {
+		flds[i] = Field{Key: prefix + fld.Key, Value: fld.Value}
+	}
+	return flds
+}
+
+//WithFields returns child logger of std with structured fields.
+func WithFields(fields Fields) *Logger { return std.WithFields(fields) }
+
+//WithFieldsAtLevel returns child logger of std with structured fields emitted only at level lv or more verbose levels.
+func WithFieldsAtLevel(lv Level, fields Fields) *Logger { return std.WithFieldsAtLevel(lv, fields) }
+
+//WithFieldPrefix returns child logger of std which prefixes keys of structured fields.
+func WithFieldPrefix(prefix string) *Logger { return std.WithFieldPrefix(prefix) }
+
+//WithLazyField returns child logger of std with structured field evaluated lazily.
+func WithLazyField(key string, fn func() interface{}) *Logger { return std.WithLazyField(key, fn) }
+
+//Dur returns child logger of std with duration field.
+func Dur(key string, d time.Duration) *Logger { return std.Dur(key, d) }
+
+//Time returns child logger of std with time field.
+func Time(key string, t time.Time) *Logger { return std.Time(key, t) }
+
+//WithTTL returns child logger of std with "ttl" field.
+func WithTTL(d time.Duration) *Logger { return std.WithTTL(d) }
+
+//Bool returns child logger of std with boolean field.
+func Bool(key string, b bool) *Logger { return std.Bool(key, b) }
+
+//Float returns child logger of std with floating-point field.
+func Float(key string, f float64) *Logger { return std.Float(key, f) }
+
+//Uint returns child logger of std with unsigned integer field.
+func Uint(key string, u uint64) *Logger { return std.Uint(key, u) }
+
+//WithEnvFields returns function for attaching values of environment variables as structured fields.
+//Field names are lowercased variable names (e.g. "POD_NAME" is "pod_name"). Missing variables are skipped.
+func WithEnvFields(names ...string) OptFunc {
+	return func(l *Logger) {
+		var fields []Field
+		for _, name := range names {
+			if v, ok := os.LookupEnv(name); ok {
+				fields = append(fields, Field{Key: strings.ToLower(name), Value: v})
+			}
+		}
+		l.fields = l.fields.push(fields)
+	}
+}
+
+//WithMaxFields returns function for setting max number of structured fields rendered in an event.
+//Extra fields are dropped and "fields_truncated=true" field is appended. If n is 0, it is unlimited.
+func WithMaxFields(n int) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.maxFields = n
+	}
+}
+
+//capFields returns fields truncated by WithMaxFields option.
+func (l *Logger) capFields(fields []Field) []Field {
+	l.mu.Lock()
+	n := l.maxFields
+	l.mu.Unlock()
+	if n <= 0 || len(fields) <= n {
+		return fields
+	}
+	return append(append(make([]Field, 0, n+1), fields[:n]...), Field{Key: "fields_truncated", Value: true})
+}
+
+//WithFieldTransformer returns function for setting a hook called for each structured field just before rendering.
+//fn returns new key and value of the field, or false to drop it.
+func WithFieldTransformer(fn func(key string, value interface{}) (string, interface{}, bool)) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.transform = fn
+	}
+}
+
+//transformFields returns fields transformed by WithFieldTransformer option.
+func (l *Logger) transformFields(fields []Field) []Field {
+	l.mu.Lock()
+	fn := l.transform
+	l.mu.Unlock()
+	if fn == nil || len(fields) == 0 {
+		return fields
+	}
+	res := make([]Field, 0, len(fields))
+	for _, fld := range fields {
+		if k, v, ok := fn(fld.Key, fld.Value); ok {
+			res = append(res, Field{Key: k, Value: v})
+		}
+	}
+	return res
+}
+
+//levelFields returns fields for event at level lv (level-scoped fields are dropped or unwrapped).
+func levelFields(lv Level, fields []Field) []Field {
+	var res []Field
+	for i, fld := range fields {
+		v, ok := fld.Value.(levelValue)
+		if !ok {
+			if res != nil {
+				res = append(res, fld)
+			}
+			continue
+		}
+		if res == nil {
+			res = append(make([]Field, 0, len(fields)), fields[:i]...)
+		}
+		if v.lv.GTE(lv) {
+			res = append(res, Field{Key: fld.Key, Value: v.val})
+		}
+	}
+	if res == nil {
+		return fields
+	}
+	return res
+}
+
+//resolveFields returns fields with lazy values evaluated.
+func resolveFields(fields []Field) []Field {
+	var res []Field
+	for i, fld := range fields {
+		fn, ok := fld.Value.(lazyValue)
+		if !ok {
+			if res != nil {
+				res = append(res, fld)
+			}
+			continue
+		}
+		if res == nil {
+			res = append(make([]Field, 0, len(fields)), fields[:i]...)
+		}
+		res = append(res, Field{Key: fld.Key, Value: evalLazy(fn)})
+	}
+	if res == nil {
+		return fields
+	}
+	return res
+}
+
+//eagerFields returns fields except lazy ones.
+//It is used for filtered events, lazy values are not evaluated for them.
+func eagerFields(fields []Field) []Field {
+	if len(fields) == 0 {
+		return nil
+	}
+	res := make([]Field, 0, len(fields))
+	for _, fld := range fields {
+		if _, ok := fld.Value.(lazyValue); !ok {
+			res = append(res, fld)
+		}
+	}
+	return res
+}
+
+//shareLazy returns fields with lazy values wrapped to be evaluated at most once
+//(for an event written by several loggers).
+func shareLazy(fields []Field) []Field {
+	var res []Field
+	for i, fld := range fields {
+		fn, ok := fld.Value.(lazyValue)
+		if !ok {
+			if res != nil {
+				res = append(res, fld)
+			}
+			continue
+		}
+		if res == nil {
+			res = append(make([]Field, 0, len(fields)), fields[:i]...)
+		}
+		var once sync.Once
+		var v interface{}
+		res = append(res, Field{Key: fld.Key, Value: lazyValue(func() interface{} {
+			once.Do(func() { v = evalLazy(fn) })
+			return v
+		})})
+	}
+	if res == nil {
+		return fields
+	}
+	return res
+}
+
+//evalLazy calls fn and recovers panic as "%!PANIC(...)" marker.
+func evalLazy(fn lazyValue) (v interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			v = fmt.Sprintf("%%!PANIC(%v)", r)
+		}
+	}()
+	return fn()
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,276 @@
+package logf
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrorWithErr(t *testing.T) {
+	err := errors.New("file not found")
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel|Lshortfile),
+	)
+	l.ErrorWithErr("cannot open config", err)
+	l.ErrorWithErr("no error", nil)
+	res := "fields_test.go:18: [ERROR] cannot open config error=\"file not found\"\nfields_test.go:19: [ERROR] no error\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Logger.ErrorWithErr()  = \"%v\", want \"%v\".", s, res)
+	}
+
+	outBuf2 := new(bytes.Buffer)
+	l2 := New(
+		WithWriter(outBuf2),
+		WithFormatter(JSONFormatter{}),
+		WithErrorType(true),
+	)
+	l2.ErrorWithErr("cannot open config", err)
+	res2 := `"level":"ERROR","msg":"cannot open config","error":"file not found","error_type":"*errors.errorString"}` + "\n"
+	if s := outBuf2.String(); !strings.HasSuffix(s, res2) {
+		t.Errorf("Logger.ErrorWithErr()  = \"%v\", want suffix \"%v\".", s, res2)
+	}
+}
+
+func TestErrField(t *testing.T) {
+	err := errors.New("file not found")
+	fld := Err(err)
+	if fld.Key != "error" || fld.Value != err {
+		t.Errorf("Err() = %v, want {error %v}.", fld, err)
+	}
+}
+
+func TestWithLazyField(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel),
+		WithMinLevel(INFO),
+	)
+	calls := 0
+	child := l.WithLazyField("dump", func() interface{} {
+		calls++
+		return "expensive value"
+	})
+	child.Debug("Debugging")
+	if calls != 0 {
+		t.Errorf("lazy field is called %d times for filtered message, want 0.", calls)
+	}
+	child.Print("Information")
+	if calls != 1 {
+		t.Errorf("lazy field is called %d times, want 1.", calls)
+	}
+	res := "[INFO] Information dump=\"expensive value\"\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Logger.Print()  = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel),
+	)
+	child := l.WithFields(Fields{"user": "alice", "id": 1})
+	grandchild := child.WithFields(Fields{"req": "abc"})
+	l.Print("parent")
+	child.Print("child")
+	grandchild.Print("grandchild")
+	l.SetMinLevel(WARN)
+	child.Print("filtered")
+	grandchild.ErrorWithErr("failed", errors.New("timeout"))
+	res := "[INFO] parent\n[INFO] child id=1 user=alice\n[INFO] grandchild id=1 user=alice req=abc\n[ERROR] failed id=1 user=alice req=abc error=timeout\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Logger output  = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestDurTime(t *testing.T) {
+	at := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	textBuf := new(bytes.Buffer)
+	l := New(WithWriter(textBuf), WithFlags(Llevel))
+	l.Dur("latency", 1500*time.Microsecond).Time("at", at).Print("done")
+	res := "[INFO] done latency=1.5 at=2009-11-10T23:00:00Z\n"
+	if s := textBuf.String(); s != res {
+		t.Errorf("Logger.Dur().Time() = \"%v\", want \"%v\".", s, res)
+	}
+
+	jsonBuf := new(bytes.Buffer)
+	l = New(WithWriter(jsonBuf), WithFormatter(JSONFormatter{}))
+	l.Dur("latency", 2*time.Second).Time("at", at).Print("done")
+	res = `"msg":"done","latency":2000,"at":"2009-11-10T23:00:00Z"}`
+	if s := jsonBuf.String(); !strings.HasSuffix(s, res+"\n") {
+		t.Errorf("Logger.Dur().Time() = \"%v\", want suffix \"%v\".", s, res)
+	}
+}
+
+func TestOutputFields(t *testing.T) {
+	textBuf := new(bytes.Buffer)
+	l := New(WithWriter(textBuf), WithFlags(Llevel|Lshortfile), WithMinLevel(INFO))
+	if err := l.OutputFields(WARN, 2, "disk full", map[string]interface{}{"path": "/var", "free": 0}); err != nil {
+		t.Errorf("Logger.OutputFields() = \"%v\", want nil.", err)
+	}
+	_ = l.OutputFields(DEBUG, 2, "filtered", map[string]interface{}{"a": 1})
+	l.WithFields(Fields{"user": "alice"}).Infow("login", Fields{"ip": "192.0.2.1"})
+	res := "fields_test.go:114: [WARN] disk full free=0 path=/var\nfields_test.go:118: [INFO] login user=alice ip=192.0.2.1\n"
+	if s := textBuf.String(); s != res {
+		t.Errorf("Logger.OutputFields() = \"%v\", want \"%v\".", s, res)
+	}
+
+	jsonBuf := new(bytes.Buffer)
+	l = New(WithWriter(jsonBuf), WithFormatter(JSONFormatter{}))
+	l.Errorw("disk full", Fields{"path": "/var", "free": 0})
+	res = `"level":"ERROR","msg":"disk full","free":0,"path":"/var"}`
+	if s := jsonBuf.String(); !strings.HasSuffix(s, res+"\n") {
+		t.Errorf("Logger.Errorw() = \"%v\", want suffix \"%v\".", s, res)
+	}
+}
+
+func TestWithFieldsAtLevel(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel)).WithFields(Fields{"id": 1}).WithFieldsAtLevel(DEBUG, Fields{"body": "{}"})
+	_ = l.Output(TRACE, 2, "trace")
+	_ = l.Output(DEBUG, 2, "debug")
+	l.Print("info")
+	res := "[TRACE] trace id=1 body={}\n[DEBUG] debug id=1 body={}\n[INFO] info id=1\n"
+	if s := buf.String(); s != res {
+		t.Errorf("Logger.WithFieldsAtLevel() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestBoolFloatUint(t *testing.T) {
+	textBuf := new(bytes.Buffer)
+	l := New(WithWriter(textBuf), WithFlags(Llevel))
+	l.Bool("ok", true).Float("ratio", 0.25).Float("big", 1e21).Uint("n", 18446744073709551615).Print("done")
+	res := "[INFO] done ok=true ratio=0.25 big=1e+21 n=18446744073709551615\n"
+	if s := textBuf.String(); s != res {
+		t.Errorf("Logger.Bool().Float().Uint() = \"%v\", want \"%v\".", s, res)
+	}
+
+	jsonBuf := new(bytes.Buffer)
+	l = New(WithWriter(jsonBuf), WithFormatter(JSONFormatter{}))
+	l.Bool("ok", false).Float("ratio", 1.0/3).Float("big", 1e21).Uint("n", 7).Print("done")
+	res = `"msg":"done","ok":false,"ratio":0.3333333333333333,"big":1e+21,"n":7}`
+	if s := jsonBuf.String(); !strings.HasSuffix(s, res+"\n") {
+		t.Errorf("Logger.Bool().Float().Uint() = \"%v\", want suffix \"%v\".", s, res)
+	}
+}
+
+func TestWithMaxFields(t *testing.T) {
+	testCase := []struct {
+		n int
+		s string
+	}{
+		{n: 0, s: "[INFO] hello a=1 b=2 c=3 d=4\n"},
+		{n: 4, s: "[INFO] hello a=1 b=2 c=3 d=4\n"},
+		{n: 2, s: "[INFO] hello a=1 b=2 fields_truncated=true\n"},
+	}
+	for _, tst := range testCase {
+		buf := new(bytes.Buffer)
+		l := New(WithWriter(buf), WithFlags(Llevel), WithMaxFields(tst.n))
+		l.WithFields(Fields{"a": 1, "b": 2}).Infow("hello", Fields{"c": 3, "d": 4})
+		if s := buf.String(); s != tst.s {
+			t.Errorf("WithMaxFields(%d) = \"%v\", want \"%v\".", tst.n, s, tst.s)
+		}
+	}
+}
+
+func TestWithFieldTransformer(t *testing.T) {
+	fn := func(key string, value interface{}) (string, interface{}, bool) {
+		switch key {
+		case "User":
+			return strings.ToLower(key), value, true
+		case "password":
+			return key, nil, false
+		case "id":
+			return key, value.(int) * 10, true
+		default:
+			return key, value, true
+		}
+	}
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel), WithFieldTransformer(fn))
+	l.Infow("hello", Fields{"User": "alice", "password": "secret", "id": 1, "x": true})
+	if s, want := buf.String(), "[INFO] hello user=alice id=10 x=true\n"; s != want {
+		t.Errorf("WithFieldTransformer() = \"%v\", want \"%v\".", s, want)
+	}
+	buf.Reset()
+	l.SetFormatter(JSONFormatter{})
+	l.Infow("hello", Fields{"password": "secret"})
+	if s := buf.String(); strings.Contains(s, "password") || strings.Contains(s, "secret") {
+		t.Errorf("WithFieldTransformer() = \"%v\", want password dropped.", s)
+	}
+}
+
+func TestWithEnvFields(t *testing.T) {
+	t.Setenv("LOGF_POD_NAME", "web-1")
+	t.Setenv("LOGF_NODE_NAME", "node-a")
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel), WithEnvFields("LOGF_POD_NAME", "LOGF_NODE_NAME", "LOGF_MISSING_NAME"))
+	l.Infow("hello", Fields{"id": 1})
+	if s, want := buf.String(), "[INFO] hello logf_pod_name=web-1 logf_node_name=node-a id=1\n"; s != want {
+		t.Errorf("WithEnvFields() = \"%v\", want \"%v\".", s, want)
+	}
+}
+
+func TestWithTTL(t *testing.T) {
+	testCase := []struct {
+		f Format
+		d time.Duration
+		s string
+	}{
+		{f: TEXT, d: 24 * time.Hour, s: " ttl=86400\n"},
+		{f: LOGFMT, d: 1500 * time.Millisecond, s: " ttl=1.5\n"},
+		{f: JSON, d: 30 * time.Second, s: `,"ttl":30}` + "\n"},
+	}
+	for _, tst := range testCase {
+		buf := new(bytes.Buffer)
+		l := New(WithWriter(buf), WithFlags(Llevel), WithFormat(tst.f))
+		l.WithTTL(tst.d).Print("cached")
+		if s := buf.String(); !strings.HasSuffix(s, tst.s) {
+			t.Errorf("Logger.WithTTL(%v) in %v = \"%v\", want suffix \"%v\".", tst.d, tst.f, s, tst.s)
+		}
+	}
+}
+
+func TestWithFieldPrefix(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFormat(JSON)).WithFields(Fields{"app": "web"})
+	db := l.WithFieldPrefix("db.")
+	db.WithFields(Fields{"query": "SELECT 1"}).Infow("done", Fields{"rows": 1})
+	db.WithFieldPrefix("tx.").Infow("commit", Fields{"id": 7})
+	l.Infow("served", Fields{"status": 200})
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	testCase := []string{
+		`"msg":"done","app":"web","db.query":"SELECT 1","db.rows":1}`,
+		`"msg":"commit","app":"web","db.tx.id":7}`,
+		`"msg":"served","app":"web","status":200}`,
+	}
+	if len(lines) != len(testCase) {
+		t.Fatalf("WithFieldPrefix() output = \"%v\", want %d lines.", buf.String(), len(testCase))
+	}
+	for i, res := range testCase {
+		if !strings.HasSuffix(lines[i], res) {
+			t.Errorf("WithFieldPrefix() output = \"%v\", want suffix \"%v\".", lines[i], res)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
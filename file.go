@@ -0,0 +1,108 @@
+package logf
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+//WithOutputFile returns function for setting file of path (opened in append mode) as Writer.
+//If mkdir is true, the parent directory is created if it does not exist.
+//The file is opened when the function is applied (by New function), owned by the logger and closed by Close method.
+//If the file cannot be opened, the output is not changed and ErrOpenOutputFile is reported to error handler.
+func WithOutputFile(path string, perm os.FileMode, mkdir bool) OptFunc {
+	return func(l *Logger) {
+		if err := l.openOutputFile(path, perm, mkdir); err != nil {
+			l.handleError(fmt.Errorf("%w: %v", ErrOpenOutputFile, err))
+		}
+	}
+}
+
+//openOutputFile opens file of path (in append mode) and sets it as Writer owned by l.
+func (l *Logger) openOutputFile(path string, perm os.FileMode, mkdir bool) error {
+	if mkdir {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, perm)
+	if err != nil {
+		return err
+	}
+	l.SetOutput(file)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.owned = append(l.owned, file)
+	l.file, l.filePerm = file, perm
+	return nil
+}
+
+//Reopen closes and reopens the file of WithOutputFile option (e.g. after rotated by logrotate on SIGHUP).
+//It returns ErrNotReopenable if the output is not opened by WithOutputFile option.
+func (l *Logger) Reopen() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return ErrNotReopenable
+	}
+	old := l.file
+	file, err := os.OpenFile(old.Name(), os.O_WRONLY|os.O_APPEND|os.O_CREATE, l.filePerm)
+	if err != nil {
+		return err
+	}
+	if l.lg.Writer() == io.Writer(old) {
+		l.lg.SetOutput(file)
+	}
+	for i, c := range l.owned {
+		if c == io.Closer(old) {
+			l.owned[i] = file
+		}
+	}
+	l.file = file
+	return old.Close()
+}
+
+//Reopen calls std.Reopen() to reopen the file of the logger.
+func Reopen() error {
+	return std.Reopen()
+}
+
+//Close closes files owned by the logger (opened by WithOutputFile option).
+//Buffered data of the writer implementing Flusher interface and pending summary of WithBurstSummary option
+//are written before that.
+func (l *Logger) Close() error {
+	err := l.flush()
+	l.mu.Lock()
+	owned := l.owned
+	l.owned, l.file = nil, nil
+	l.mu.Unlock()
+	for _, c := range owned {
+		if e := c.Close(); err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+//Close calls std.Close() to close files owned by the logger.
+func Close() error {
+	return std.Close()
+}
+
+var _ io.Closer = (*Logger)(nil)
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,103 @@
+package logf
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithOutputFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "sub", "app.log")
+	var errs []error
+	New(WithErrorHandler(func(err error) { errs = append(errs, err) }), WithOutputFile(path, 0644, false))
+	if len(errs) != 1 || !errors.Is(errs[0], ErrOpenOutputFile) {
+		t.Errorf("WithOutputFile() errors = %v, want %v without directory.", errs, ErrOpenOutputFile)
+	}
+	for i := 0; i < 2; i++ {
+		opt := WithOutputFile(path, 0644, true)
+		if _, err := os.Stat(path); i == 0 && err == nil {
+			t.Error("WithOutputFile() opens the file before applied.")
+		}
+		l := New(opt, WithFlags(Llevel))
+		l.Print("hello")
+		if err := l.Close(); err != nil {
+			t.Errorf("Logger.Close() = \"%v\", want nil.", err)
+		}
+		if err := l.Close(); err != nil {
+			t.Errorf("Logger.Close() twice = \"%v\", want nil.", err)
+		}
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := "[INFO] hello\n[INFO] hello\n"
+	if s := string(b); s != res {
+		t.Errorf("WithOutputFile() content = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "app.log")
+	l := New(WithOutputFile(path, 0644, false), WithFlags(Llevel))
+	l.Print("before")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Reopen(); err != nil {
+		t.Errorf("Logger.Reopen() = \"%v\", want nil.", err)
+	}
+	l.Print("after")
+	if err := l.Close(); err != nil {
+		t.Errorf("Logger.Close() = \"%v\", want nil.", err)
+	}
+	testCase := []struct {
+		path string
+		s    string
+	}{
+		{path: path + ".1", s: "[INFO] before\n"},
+		{path: path, s: "[INFO] after\n"},
+	}
+	for _, tst := range testCase {
+		b, err := ioutil.ReadFile(tst.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := string(b); s != tst.s {
+			t.Errorf("%s content = \"%v\", want \"%v\".", filepath.Base(tst.path), s, tst.s)
+		}
+	}
+	if err := l.Reopen(); !errors.Is(err, ErrNotReopenable) {
+		t.Errorf("Logger.Reopen() after Close() = \"%v\", want \"%v\".", err, ErrNotReopenable)
+	}
+	if err := New(WithWriter(ioutil.Discard)).Reopen(); !errors.Is(err, ErrNotReopenable) {
+		t.Errorf("Logger.Reopen() = \"%v\", want \"%v\".", err, ErrNotReopenable)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,55 @@
+package logf
+
+import "sync/atomic"
+
+//WithFieldFilter returns function for dropping events by their structured fields
+//(e.g. events with env=test field). fn receives fields of the event (keys of groups are dotted)
+//and returns false to drop it; dropped events are counted by Suppressed method.
+func WithFieldFilter(fn func(fields map[string]interface{}) bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.fieldFilter = fn
+	}
+}
+
+//filterFields reports whether the event with fields passes the filter of WithFieldFilter option.
+//Dropped events are counted.
+func (l *Logger) filterFields(fields []Field) bool {
+	l.mu.Lock()
+	fn := l.fieldFilter
+	l.mu.Unlock()
+	if fn == nil {
+		return true
+	}
+	m := make(map[string]interface{}, len(fields))
+	for _, fld := range flattenFields(fields) {
+		m[fld.Key] = fld.Value
+	}
+	if !fn(m) {
+		atomic.AddUint64(&l.suppressed, 1)
+		return false
+	}
+	return true
+}
+
+//Suppressed returns count of logging events dropped by the filter of WithFieldFilter option.
+func (l *Logger) Suppressed() uint64 { return atomic.LoadUint64(&l.suppressed) }
+
+//Suppressed calls std.Suppressed() to get count of events dropped by field filter.
+func Suppressed() uint64 { return std.Suppressed() }
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
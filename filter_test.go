@@ -0,0 +1,67 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWithFieldFilter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel), WithFieldFilter(func(fields map[string]interface{}) bool {
+		return fields["env"] != "test" && fields["http.path"] != "/health"
+	}))
+	l.WithFields(Fields{"env": "test"}).Print("dropped")
+	l.WithFields(Fields{"env": "prod"}).Print("kept")
+	l.Print("no fields")
+	l.Infow("dropped", Fields{"env": "test"})
+	l.WithGroup("http").Infow("health check", Fields{"path": "/health"})
+	l.WithGroup("http").Infow("request", Fields{"path": "/"})
+	res := "[INFO] kept env=prod\n[INFO] no fields\n[INFO] request http.path=/\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WithFieldFilter() = \"%v\", want \"%v\".", s, res)
+	}
+	if n := l.Suppressed(); n != 3 {
+		t.Errorf("Logger.Suppressed() = %d, want 3.", n)
+	}
+}
+
+func TestWithFieldFilterBlockFprintf(t *testing.T) {
+	out := new(bytes.Buffer)
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(out), WithFlags(Llevel), WithFieldFilter(func(fields map[string]interface{}) bool {
+		return fields["env"] != "test"
+	}))
+	test := l.WithFields(Fields{"env": "test"})
+	if err := test.Block(INFO, "one", "two"); err != nil {
+		t.Errorf("Block() = \"%v\", want nil.", err)
+	}
+	if err := test.Fprintf(buf, INFO, "dropped"); err != nil {
+		t.Errorf("Fprintf() = \"%v\", want nil.", err)
+	}
+	_ = l.WithFields(Fields{"env": "prod"}).Block(INFO, "kept")
+	_ = l.WithFields(Fields{"env": "prod"}).Fprintf(buf, INFO, "kept")
+	if s, res := out.String(), "[INFO] kept env=prod\n"; s != res {
+		t.Errorf("Block() = \"%v\", want \"%v\".", s, res)
+	}
+	if s, res := buf.String(), "[INFO] kept env=prod\n"; s != res {
+		t.Errorf("Fprintf() = \"%v\", want \"%v\".", s, res)
+	}
+	if n := l.Suppressed(); n != 2 {
+		t.Errorf("Logger.Suppressed() = %d, want 2.", n)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,571 @@
+package logf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Formatter is interface for formatting a logging event.
+//Returned bytes are written to the output as one log entry
+//(a newline is appended if missing).
+type Formatter interface {
+	Format(lv Level, prefix string, t time.Time, msg string) []byte
+}
+
+//FieldFormatter is Formatter which renders structured fields as well.
+type FieldFormatter interface {
+	Formatter
+	FormatFields(lv Level, prefix string, t time.Time, msg string, fields []Field) []byte
+}
+
+//Field is a structured field of logging event.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+//Fields is a set of structured fields (key and value).
+type Fields map[string]interface{}
+
+//Format is a predefined output format
+type Format int
+
+//Values of Format
+const (
+	TEXT   Format = iota // text format (compatible with log package)
+	JSON                 // JSONFormatter
+	LOGFMT               // LogfmtFormatter
+	GELF                 // GELFFormatter
+	CSV                  // CSVFormatter
+)
+
+var formatMap = map[Format]string{
+	TEXT:   "text",
+	JSON:   "json",
+	LOGFMT: "logfmt",
+	GELF:   "gelf",
+	CSV:    "csv",
+}
+
+func (f Format) String() string {
+	if s, ok := formatMap[f]; ok {
+		return s
+	}
+	return ""
+}
+
+//formatter returns Formatter of f (nil for TEXT or undefined format).
+func (f Format) formatter() Formatter {
+	switch f {
+	case JSON:
+		return JSONFormatter{}
+	case LOGFMT:
+		return LogfmtFormatter{}
+	case GELF:
+		return GELFFormatter{}
+	case CSV:
+		return CSVFormatter{}
+	default:
+		return nil
+	}
+}
+
+//WithFormat returns function for setting predefined output format.
+func WithFormat(f Format) OptFunc {
+	return func(l *Logger) {
+		l.SetFormatter(f.formatter())
+	}
+}
+
+//WithFormatter returns function for setting Formatter.
+//If f is nil, the standard text format (log package compatible) is used.
+func WithFormatter(f Formatter) OptFunc {
+	return func(l *Logger) {
+		l.SetFormatter(f)
+	}
+}
+
+//SetFormatter sets Formatter for the logger.
+func (l *Logger) SetFormatter(f Formatter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.formatter = f
+}
+
+//SetFormatter sets Formatter for the logger.
+func SetFormatter(f Formatter) { std.SetFormatter(f) }
+
+//staticFields returns the fields fixed at construction.
+func (l *Logger) staticFields() []Field {
+	fields := []Field{}
+	if len(l.host) > 0 {
+		fields = append(fields, Field{Key: "host", Value: l.host})
+	}
+	if l.pid > 0 {
+		fields = append(fields, Field{Key: "pid", Value: l.pid})
+	}
+	return append(fields, l.buildFields()...)
+}
+
+//format writes a logging event by Formatter.
+//calldepth is the same as l.lg.Output().
+func (l *Logger) format(lv Level, calldepth int, s string, fields []Field) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.formatBytes(l.formatter, lv, calldepth, s, fields)
+	if _, ok := l.formatter.(CSVFormatter); ok && l.csvHeader && !l.csvHeaderDone {
+		b = append([]byte(csvHeader), b...)
+		l.csvHeaderDone = true
+	}
+	return l.write(lv, b)
+}
+
+//formatBytes returns a logging event formatted by f.
+//It must be called with l.mu held.
+func (l *Logger) formatBytes(f Formatter, lv Level, calldepth int, s string, fields []Field) []byte {
+	t := l.clock()
+	if (l.flags() & LUTC) != 0 {
+		t = t.UTC()
+	}
+	s = strings.TrimSuffix(s, "\n")
+	fields = append(append(l.staticFields(), callerFields(l.textFlag(lv), calldepth+1)...), fields...)
+	if lf, ok := f.(LogfmtFormatter); ok && len(lf.FieldSep)+len(lf.KVSep) == 0 {
+		lf.FieldSep, lf.KVSep = l.fieldSep, l.kvSep
+		f = lf
+	}
+	var b []byte
+	if ff, ok := f.(FieldFormatter); ok {
+		b = ff.FormatFields(lv, l.prefix(lv), t, s, fields)
+	} else {
+		b = f.Format(lv, l.prefix(lv), t, s+l.renderFields(fields))
+	}
+	if len(b) == 0 || b[len(b)-1] != '\n' {
+		b = append(b, '\n')
+	}
+	return b
+}
+
+//callerFields returns "caller" field (file:line) if file flags are set.
+//calldepth is the same as l.lg.Output().
+func callerFields(flag, calldepth int) []Field {
+	if (flag & (Lshortfile | Llongfile)) == 0 {
+		return nil
+	}
+	_, file, line, ok := runtime.Caller(calldepth)
+	if !ok {
+		file, line = "???", 0
+	}
+	if (flag & Lshortfile) != 0 {
+		file = filepath.Base(file)
+	}
+	return []Field{{Key: "caller", Value: file + ":" + strconv.Itoa(line)}}
+}
+
+//textFields renders fields as " key=value" pairs.
+func textFields(fields []Field) string {
+	return joinFields(fields, " ", "=")
+}
+
+//renderFields renders fields as " key=value" pairs with separators of WithFieldSeparator and WithKVSeparator options.
+func (l *Logger) renderFields(fields []Field) string {
+	fsep, kvsep := separators(l.fieldSep, l.kvSep)
+	return joinFields(fields, fsep, kvsep)
+}
+
+//joinFields renders fields as key and value pairs, each of them preceded by fieldSep.
+//Stack fields are rendered as block in following lines.
+func joinFields(fields []Field, fieldSep, kvSep string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	buf := &bytes.Buffer{}
+	var stacks []stackTrace
+	for _, fld := range flattenFields(fields) {
+		if st, ok := fld.Value.(stackTrace); ok {
+			stacks = append(stacks, st)
+			continue
+		}
+		buf.WriteString(fieldSep)
+		buf.WriteString(fld.Key)
+		buf.WriteString(kvSep)
+		buf.WriteString(logfmtValue(fld.Value))
+	}
+	for _, st := range stacks {
+		buf.WriteByte('\n')
+		buf.WriteString(st.block())
+	}
+	return buf.String()
+}
+
+//JSONFormatter is Formatter for JSON (one object per line).
+type JSONFormatter struct {
+	Order     []string // leading keys; other keys are sorted (insertion order if nil)
+	OmitEmpty bool     // omit fields of empty or zero value
+}
+
+var _ FieldFormatter = JSONFormatter{}
+
+//jsonMember is a member of JSON object (also used for ordered logfmt pairs).
+type jsonMember struct {
+	key string
+	val []byte
+}
+
+//Format is method of Formatter interface.
+func (f JSONFormatter) Format(lv Level, prefix string, t time.Time, msg string) []byte {
+	return f.FormatFields(lv, prefix, t, msg, nil)
+}
+
+//FormatFields is method of FieldFormatter interface.
+func (f JSONFormatter) FormatFields(lv Level, prefix string, t time.Time, msg string, fields []Field) []byte {
+	members := make([]jsonMember, 0, len(fields)+4)
+	members = append(members, jsonMember{key: "time", val: []byte(strconv.Quote(t.Format(time.RFC3339Nano)))})
+	members = append(members, jsonMember{key: "level", val: []byte(strconv.Quote(lv.String()))})
+	if len(prefix) > 0 {
+		members = append(members, jsonMember{key: "prefix", val: jsonValue(prefix)})
+	}
+	members = append(members, jsonMember{key: "msg", val: jsonValue(msg)})
+	for _, fld := range fields {
+		if f.OmitEmpty && isEmpty(fld.Value) {
+			continue
+		}
+		members = append(members, jsonMember{key: fld.Key, val: jsonValue(fld.Value)})
+	}
+	if f.Order != nil {
+		members = orderMembers(members, f.Order)
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(jsonValue(m.key))
+		buf.WriteByte(':')
+		buf.Write(m.val)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+//orderMembers returns members with leading keys in order and others sorted by key.
+func orderMembers(members []jsonMember, order []string) []jsonMember {
+	rank := make(map[string]int, len(order))
+	for i, k := range order {
+		if _, ok := rank[k]; !ok {
+			rank[k] = i
+		}
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		ri, oki := rank[members[i].key]
+		rj, okj := rank[members[j].key]
+		switch {
+		case oki && okj:
+			return ri < rj
+		case oki || okj:
+			return oki
+		default:
+			return members[i].key < members[j].key
+		}
+	})
+	return members
+}
+
+//isEmpty reports whether v is nil, zero value, or empty string, slice or map.
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+//WithJSONFieldOrder returns function for setting JSONFormatter with leading keys in order (e.g. "time", "level", "msg").
+//Other keys are sorted, so that output is deterministic.
+func WithJSONFieldOrder(keys []string) OptFunc {
+	return func(l *Logger) {
+		l.updateJSONFormatter(func(f *JSONFormatter) {
+			f.Order = append([]string{}, keys...)
+		})
+	}
+}
+
+//WithOmitEmpty returns function for setting JSONFormatter which omits fields of empty or zero value.
+func WithOmitEmpty(omit bool) OptFunc {
+	return func(l *Logger) {
+		l.updateJSONFormatter(func(f *JSONFormatter) {
+			f.OmitEmpty = omit
+		})
+	}
+}
+
+//updateJSONFormatter sets JSONFormatter updated by fn (keeps current settings if formatter is JSONFormatter).
+func (l *Logger) updateJSONFormatter(fn func(*JSONFormatter)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, _ := l.formatter.(JSONFormatter)
+	fn(&f)
+	l.formatter = f
+}
+
+//jsonValue returns JSON encoding of v.
+//Panic in methods of v (Error(), MarshalJSON(), etc.) is recovered as "%!PANIC(...)" marker.
+func jsonValue(v interface{}) (b []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			b, _ = json.Marshal(fmt.Sprintf("%%!PANIC(%v)", r))
+		}
+	}()
+	if err, ok := v.(error); ok {
+		v = err.Error()
+	}
+	if g, ok := v.(fieldGroup); ok {
+		return jsonGroup(g)
+	}
+	if rv, ok := collection(v); ok {
+		return jsonCollection(rv)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprintf("%+v", v))
+	}
+	return b
+}
+
+//LogfmtFormatter is Formatter for logfmt (key=value pairs).
+type LogfmtFormatter struct {
+	Order    []string // leading keys; other keys are sorted (insertion order if nil)
+	FieldSep string   // separator between pairs (space if empty)
+	KVSep    string   // separator between key and value ("=" if empty)
+}
+
+var _ FieldFormatter = LogfmtFormatter{}
+
+//Format is method of Formatter interface.
+func (f LogfmtFormatter) Format(lv Level, prefix string, t time.Time, msg string) []byte {
+	return f.FormatFields(lv, prefix, t, msg, nil)
+}
+
+//FormatFields is method of FieldFormatter interface.
+func (f LogfmtFormatter) FormatFields(lv Level, prefix string, t time.Time, msg string, fields []Field) []byte {
+	members := make([]jsonMember, 0, len(fields)+4)
+	members = append(members, jsonMember{key: "time", val: []byte(t.Format(time.RFC3339Nano))})
+	members = append(members, jsonMember{key: "level", val: []byte(lv.String())})
+	if len(prefix) > 0 {
+		members = append(members, jsonMember{key: "prefix", val: []byte(logfmtValue(prefix))})
+	}
+	members = append(members, jsonMember{key: "msg", val: []byte(logfmtValue(msg))})
+	for _, fld := range flattenFields(fields) {
+		members = append(members, jsonMember{key: fld.Key, val: []byte(logfmtValue(fld.Value))})
+	}
+	if f.Order != nil {
+		members = orderMembers(members, f.Order)
+	}
+	fsep, kvsep := separators(f.FieldSep, f.KVSep)
+	buf := &bytes.Buffer{}
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteString(fsep)
+		}
+		buf.WriteString(m.key)
+		buf.WriteString(kvsep)
+		buf.Write(m.val)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+//WithLogfmtFieldOrder returns function for setting LogfmtFormatter with leading keys in order (e.g. "time", "level", "msg").
+//Other keys are sorted, so that output is deterministic.
+func WithLogfmtFieldOrder(keys []string) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		f, _ := l.formatter.(LogfmtFormatter)
+		f.Order = append([]string{}, keys...)
+		l.formatter = f
+	}
+}
+
+//WithFieldSeparator returns function for setting separator between key=value pairs of fields
+//in text format and LogfmtFormatter (default is space; e.g. "|" or "\t").
+func WithFieldSeparator(sep string) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.fieldSep = sep
+	}
+}
+
+//WithKVSeparator returns function for setting separator between key and value of fields
+//in text format and LogfmtFormatter (default is "="; e.g. ":").
+func WithKVSeparator(sep string) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.kvSep = sep
+	}
+}
+
+//separators returns separators between pairs and between key and value (defaults if empty).
+func separators(fieldSep, kvSep string) (string, string) {
+	if len(fieldSep) == 0 {
+		fieldSep = " "
+	}
+	if len(kvSep) == 0 {
+		kvSep = "="
+	}
+	return fieldSep, kvSep
+}
+
+//logfmtString returns string of v.
+func logfmtString(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case error:
+		return safeString(val.Error)
+	case fieldGroup:
+		return string(jsonGroup(val))
+	case stackTrace:
+		return val.block()
+	default:
+		if rv, ok := collection(v); ok {
+			return logfmtCollection(rv)
+		}
+		return sprintf("%+v", v)
+	}
+}
+
+//logfmtValue returns string of v, quoted if needed.
+func logfmtValue(v interface{}) string {
+	s := logfmtString(v)
+	if _, ok := collection(v); ok || len(s) == 0 || strings.IndexFunc(s, needsQuote) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func needsQuote(r rune) bool {
+	return r <= ' ' || r == '=' || r == '"' || r == 0x7f
+}
+
+//collection returns reflect.Value of v if v is slice, array or map (except []byte).
+func collection(v interface{}) (reflect.Value, bool) {
+	if v == nil {
+		return reflect.Value{}, false
+	}
+	if _, ok := v.([]byte); ok {
+		return reflect.Value{}, false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv, rv.Kind() != reflect.Slice || !rv.IsNil()
+	default:
+		return rv, false
+	}
+}
+
+//sortedMapKeys returns keys of map rv and their string forms sorted by the string.
+func sortedMapKeys(rv reflect.Value) ([]reflect.Value, []string) {
+	keys := rv.MapKeys()
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = logfmtString(k.Interface())
+	}
+	sort.Sort(mapKeys{keys: keys, names: names})
+	return keys, names
+}
+
+//mapKeys sorts keys of map by their string forms.
+type mapKeys struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (m mapKeys) Len() int           { return len(m.keys) }
+func (m mapKeys) Less(i, j int) bool { return m.names[i] < m.names[j] }
+func (m mapKeys) Swap(i, j int) {
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+	m.names[i], m.names[j] = m.names[j], m.names[i]
+}
+
+//jsonCollection returns JSON array (slice and array) or object (map; keys are sorted) of rv.
+func jsonCollection(rv reflect.Value) []byte {
+	buf := &bytes.Buffer{}
+	if rv.Kind() == reflect.Map {
+		keys, names := sortedMapKeys(rv)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.Quote(names[i]))
+			buf.WriteByte(':')
+			buf.Write(jsonValue(rv.MapIndex(k).Interface()))
+		}
+		buf.WriteByte('}')
+		return buf.Bytes()
+	}
+	buf.WriteByte('[')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(jsonValue(rv.Index(i).Interface()))
+	}
+	buf.WriteByte(']')
+	return buf.Bytes()
+}
+
+//logfmtCollection returns comma-joined elements (slice and array) or key:value pairs (map; keys are sorted) of rv.
+//Nested collections are rendered in JSON.
+func logfmtCollection(rv reflect.Value) string {
+	elem := func(v interface{}) string {
+		if _, ok := collection(v); ok {
+			return string(jsonValue(v))
+		}
+		return logfmtString(v)
+	}
+	items := []string{}
+	if rv.Kind() == reflect.Map {
+		keys, names := sortedMapKeys(rv)
+		for i, k := range keys {
+			items = append(items, names[i]+":"+elem(rv.MapIndex(k).Interface()))
+		}
+	} else {
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, elem(rv.Index(i).Interface()))
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,261 @@
+package logf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testFormatter struct{}
+
+func (f testFormatter) Format(lv Level, prefix string, t time.Time, msg string) []byte {
+	return []byte(fmt.Sprintf("%s%s|%s", prefix, lv, msg))
+}
+
+func TestCustomFormatter(t *testing.T) {
+	testCase := []struct {
+		l Level
+		m string
+		s string
+	}{
+		{l: TRACE, m: "Tracing", s: ""},
+		{l: INFO, m: "Information", s: "[TEST] INFO|Information\n"},
+		{l: ERROR, m: "Erroring\n", s: "[TEST] ERROR|Erroring\n"},
+	}
+	for _, tst := range testCase {
+		outBuf := new(bytes.Buffer)
+		l := New(
+			WithWriter(outBuf),
+			WithPrefix("[TEST] "),
+			WithMinLevel(INFO),
+			WithFormatter(testFormatter{}),
+		)
+		if err := l.Output(tst.l, 2, tst.m); err != nil {
+			t.Errorf("Result of Logger.Output()  = %v, want nil.", err)
+		}
+		s := outBuf.String()
+		if s != tst.s {
+			t.Errorf("Logger.Output(%d, \"%s\")  = \"%v\", want \"%v\".", int(tst.l), tst.m, s, tst.s)
+		}
+	}
+}
+
+func TestCustomFormatterWithFields(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithPID(),
+		WithFormatter(testFormatter{}),
+	)
+	l.Print("Information")
+	res := fmt.Sprintf("INFO|Information pid=%d\n", os.Getpid())
+	if s := outBuf.String(); s != res {
+		t.Errorf("Logger.Print()  = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	tm := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	testCase := []struct {
+		l      Level
+		prefix string
+		m      string
+		fields []Field
+		s      string
+	}{
+		{l: INFO, m: "Information", s: `{"time":"2009-11-10T23:00:00Z","level":"INFO","msg":"Information"}` + "\n"},
+		{l: WARN, prefix: "[TEST] ", m: "say \"hello\"", s: `{"time":"2009-11-10T23:00:00Z","level":"WARN","prefix":"[TEST] ","msg":"say \"hello\""}` + "\n"},
+		{l: ERROR, m: "Erroring", fields: []Field{{Key: "host", Value: "localhost"}, {Key: "pid", Value: 123}, {Key: "err", Value: os.ErrNotExist}}, s: `{"time":"2009-11-10T23:00:00Z","level":"ERROR","msg":"Erroring","host":"localhost","pid":123,"err":"file does not exist"}` + "\n"},
+	}
+	for _, tst := range testCase {
+		s := string(JSONFormatter{}.FormatFields(tst.l, tst.prefix, tm, tst.m, tst.fields))
+		if s != tst.s {
+			t.Errorf("JSONFormatter.FormatFields()  = \"%v\", want \"%v\".", s, tst.s)
+		}
+	}
+}
+
+func TestLogfmtFormatter(t *testing.T) {
+	tm := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	testCase := []struct {
+		l      Level
+		prefix string
+		m      string
+		fields []Field
+		s      string
+	}{
+		{l: INFO, m: "Information", s: "time=2009-11-10T23:00:00Z level=INFO msg=Information\n"},
+		{l: WARN, prefix: "[TEST] ", m: "say \"hello\"", s: "time=2009-11-10T23:00:00Z level=WARN prefix=\"[TEST] \" msg=\"say \\\"hello\\\"\"\n"},
+		{l: ERROR, m: "", fields: []Field{{Key: "host", Value: "localhost"}, {Key: "pid", Value: 123}, {Key: "err", Value: os.ErrNotExist}}, s: "time=2009-11-10T23:00:00Z level=ERROR msg=\"\" host=localhost pid=123 err=\"file does not exist\"\n"},
+	}
+	for _, tst := range testCase {
+		s := string(LogfmtFormatter{}.FormatFields(tst.l, tst.prefix, tm, tst.m, tst.fields))
+		if s != tst.s {
+			t.Errorf("LogfmtFormatter.FormatFields()  = \"%v\", want \"%v\".", s, tst.s)
+		}
+	}
+}
+
+func TestJSONOutputWithHostnameAndPID(t *testing.T) {
+	host, err := os.Hostname()
+	if err != nil || len(host) == 0 {
+		host = "unknown"
+	}
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithHostname(),
+		WithPID(),
+		WithFormatter(JSONFormatter{}),
+	)
+	l.Print("Information")
+	res := fmt.Sprintf(`"msg":"Information","host":"%s","pid":%d}`+"\n", host, os.Getpid())
+	if s := outBuf.String(); !strings.HasSuffix(s, res) {
+		t.Errorf("Logger.Print()  = \"%v\", want suffix \"%v\".", s, res)
+	}
+}
+
+func TestJSONFormatterOrder(t *testing.T) {
+	tm := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	fields := []Field{{Key: "user", Value: "alice"}, {Key: "empty", Value: ""}, {Key: "count", Value: 0}, {Key: "id", Value: 1}, {Key: "tags", Value: []string{}}, {Key: "err", Value: nil}}
+	testCase := []struct {
+		f JSONFormatter
+		s string
+	}{
+		{f: JSONFormatter{}, s: `{"time":"2009-11-10T23:00:00Z","level":"INFO","msg":"login","user":"alice","empty":"","count":0,"id":1,"tags":[],"err":null}` + "\n"},
+		{f: JSONFormatter{Order: []string{"time", "level", "msg"}}, s: `{"time":"2009-11-10T23:00:00Z","level":"INFO","msg":"login","count":0,"empty":"","err":null,"id":1,"tags":[],"user":"alice"}` + "\n"},
+		{f: JSONFormatter{Order: []string{"msg", "user"}, OmitEmpty: true}, s: `{"msg":"login","user":"alice","id":1,"level":"INFO","time":"2009-11-10T23:00:00Z"}` + "\n"},
+	}
+	for _, tst := range testCase {
+		s := string(tst.f.FormatFields(INFO, "", tm, "login", fields))
+		if s != tst.s {
+			t.Errorf("JSONFormatter.FormatFields()  = \"%v\", want \"%v\".", s, tst.s)
+		}
+	}
+}
+
+func TestWithJSONFieldOrder(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithOmitEmpty(true), WithJSONFieldOrder([]string{"msg", "level"}))
+	l.WithFields(Fields{"b": 2, "a": 1, "z": ""}).Print("hello")
+	s := buf.String()
+	if !strings.HasPrefix(s, `{"msg":"hello","level":"INFO","a":1,"b":2,"time":"`) || strings.Contains(s, `"z"`) {
+		t.Errorf("WithJSONFieldOrder()  = \"%v\", want ordered keys without empty field.", s)
+	}
+}
+
+func TestJSONCallerField(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Lshortfile|Llevel), WithFormat(JSON))
+	l.Print("hello")
+	s := buf.String()
+	if !strings.Contains(s, `"caller":"format_test.go:153"`) || !strings.Contains(s, `"msg":"hello"`) {
+		t.Errorf("JSON output  = \"%v\", want caller field.", s)
+	}
+	buf.Reset()
+	l.SetFlags(Llevel)
+	l.Print("hello")
+	if strings.Contains(buf.String(), `"caller"`) {
+		t.Errorf("JSON output  = \"%v\", want no caller field.", buf.String())
+	}
+}
+
+func TestCollectionFields(t *testing.T) {
+	fields := Fields{
+		"ids":    []int{1, 2, 3},
+		"names":  [2]string{"a", "b c"},
+		"attrs":  map[string]interface{}{"b": 2, "a": fmt.Errorf("bad")},
+		"nested": []interface{}{[]int{1, 2}, map[int]string{2: "y", 1: "x"}},
+	}
+	testCase := []struct {
+		f Format
+		s []string
+	}{
+		{f: JSON, s: []string{`"ids":[1,2,3]`, `"names":["a","b c"]`, `"attrs":{"a":"bad","b":2}`, `"nested":[[1,2],{"1":"x","2":"y"}]`}},
+		{f: LOGFMT, s: []string{`ids="1,2,3"`, `names="a,b c"`, `attrs="a:bad,b:2"`, `nested="[1,2],{\"1\":\"x\",\"2\":\"y\"}"`}},
+		{f: TEXT, s: []string{`ids="1,2,3"`, `attrs="a:bad,b:2"`}},
+	}
+	for _, tst := range testCase {
+		buf := new(bytes.Buffer)
+		l := New(WithWriter(buf), WithFlags(Llevel), WithFormat(tst.f))
+		l.Infow("items", fields)
+		for _, want := range tst.s {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("Infow() in %v = \"%v\", want containing \"%v\".", tst.f, buf.String(), want)
+			}
+		}
+	}
+}
+
+func TestLogfmtFormatterOrder(t *testing.T) {
+	tm := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	fields := []Field{{Key: "user", Value: "alice"}, {Key: "id", Value: 1}, {Key: "err", Value: os.ErrNotExist}, {Key: "count", Value: 0}}
+	testCase := []struct {
+		f LogfmtFormatter
+		s string
+	}{
+		{f: LogfmtFormatter{}, s: "time=2009-11-10T23:00:00Z level=INFO prefix=[app] msg=login user=alice id=1 err=\"file does not exist\" count=0\n"},
+		{f: LogfmtFormatter{Order: []string{"time", "level", "msg"}}, s: "time=2009-11-10T23:00:00Z level=INFO msg=login count=0 err=\"file does not exist\" id=1 prefix=[app] user=alice\n"},
+		{f: LogfmtFormatter{Order: []string{"msg", "user"}}, s: "msg=login user=alice count=0 err=\"file does not exist\" id=1 level=INFO prefix=[app] time=2009-11-10T23:00:00Z\n"},
+	}
+	for _, tst := range testCase {
+		s := string(tst.f.FormatFields(INFO, "[app]", tm, "login", fields))
+		if s != tst.s {
+			t.Errorf("LogfmtFormatter.FormatFields()  = \"%v\", want \"%v\".", s, tst.s)
+		}
+	}
+}
+
+func TestWithLogfmtFieldOrder(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithLogfmtFieldOrder([]string{"level", "msg"}))
+	l.WithFields(Fields{"b": 2, "a": 1}).Print("hello")
+	s := buf.String()
+	if !strings.HasPrefix(s, "level=INFO msg=hello a=1 b=2 time=") {
+		t.Errorf("WithLogfmtFieldOrder()  = \"%v\", want ordered keys.", s)
+	}
+}
+
+func TestFieldSeparators(t *testing.T) {
+	testCase := []struct {
+		f    Format
+		fsep string
+		kv   string
+		s    string
+	}{
+		{f: TEXT, fsep: "|", kv: ":", s: "[INFO] login|user:alice|id:1\n"},
+		{f: TEXT, fsep: "\t", kv: "", s: "[INFO] login\tuser=alice\tid=1\n"},
+		{f: LOGFMT, fsep: "|", kv: ":", s: "level:INFO|msg:login|user:alice|id:1\n"},
+		{f: LOGFMT, fsep: "", kv: "", s: "level=INFO msg=login user=alice id=1\n"},
+	}
+	for _, tst := range testCase {
+		buf := new(bytes.Buffer)
+		l := New(WithWriter(buf), WithFlags(Llevel), WithFormat(tst.f), WithFieldSeparator(tst.fsep), WithKVSeparator(tst.kv))
+		l.WithFields(Fields{"user": "alice"}).Infow("login", Fields{"id": 1})
+		s := buf.String()
+		if tst.f == LOGFMT {
+			s = s[strings.Index(s, "level"):]
+		}
+		if s != tst.s {
+			t.Errorf("WithFieldSeparator(%q) and WithKVSeparator(%q) in %v = %q, want %q.", tst.fsep, tst.kv, tst.f, s, tst.s)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
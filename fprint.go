@@ -0,0 +1,67 @@
+package logf
+
+import "io"
+
+//Fprintf writes a logging event to w instead of the output of the logger.
+//The event is formatted by level, prefix, flags and formatter of the logger,
+//and filtered by minimum level. Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) Fprintf(w io.Writer, lv Level, format string, v ...interface{}) error {
+	return l.fprint(w, lv, l.depth-1, l.sprintf(format, v...))
+}
+
+//Fprint writes a logging event to w instead of the output of the logger.
+//Arguments are handled in the manner of fmt.Print.
+func (l *Logger) Fprint(w io.Writer, lv Level, v ...interface{}) error {
+	return l.fprint(w, lv, l.depth-1, sprint(v...))
+}
+
+//Fprintln writes a logging event to w instead of the output of the logger.
+//Arguments are handled in the manner of fmt.Println.
+func (l *Logger) Fprintln(w io.Writer, lv Level, v ...interface{}) error {
+	return l.fprint(w, lv, l.depth-1, sprintln(v...))
+}
+
+//fprint writes a logging event to w.
+//calldepth is the same as l.lg.Output().
+func (l *Logger) fprint(w io.Writer, lv Level, calldepth int, s string) error {
+	if w == nil || l.drop(1) || !lv.GTE(l.minLevel()) {
+		return nil
+	}
+	s = l.message(s)
+	fields := l.eventFields(lv)
+	if !l.filterFields(fields) {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.writeTo(w, lv, l.render(w, lv, calldepth, s, fields, false))
+}
+
+//render returns a logging event formatted by formatter of l (or in text format) for w.
+//If output is true, w is the output of the logger (time since the previous event of WithDeltaTime option is printed).
+//It must be called with l.mu held.
+func (l *Logger) render(w io.Writer, lv Level, calldepth int, s string, fields []Field, output bool) []byte {
+	if l.formatter != nil {
+		return l.formatBytes(l.formatter, lv, calldepth+1, s, fields)
+	}
+	t, delta := l.clock(), ""
+	if output {
+		delta = l.nextDelta(t)
+	}
+	return l.textBytes(lv, calldepth+1, s+l.renderFields(fields), t, l.textFlag(lv), l.colorEnabledFor(w), delta)
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,68 @@
+package logf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFprintf(t *testing.T) {
+	out := &bytes.Buffer{}
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(out), WithFlags(Llevel|Lshortfile), WithPrefix("app: "), WithMinLevel(INFO))
+	l.Fprintf(buf, WARN, "hello %s", "world")
+	l.Fprint(buf, INFO, "hello")
+	l.Fprintln(buf, DEBUG, "filtered")
+	want := "app: fprint_test.go:13: [WARN] hello world\napp: fprint_test.go:14: [INFO] hello\n"
+	if s := buf.String(); s != want {
+		t.Errorf("Fprintf() = \"%v\", want \"%v\".", s, want)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output of logger = \"%v\", want empty.", out.String())
+	}
+}
+
+func TestFprintfJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(&bytes.Buffer{}), WithFlags(Llevel|Lshortfile), WithFormat(JSON))
+	l.WithFields(Fields{"id": 1}).Fprintf(buf, INFO, "hello")
+	s := buf.String()
+	for _, want := range []string{"\"msg\":\"hello\"", "\"id\":1", "\"caller\":\"fprint_test.go:28\""} {
+		if !strings.Contains(s, want) {
+			t.Errorf("Fprintf() = \"%v\", want containing \"%v\".", s, want)
+		}
+	}
+}
+
+func TestFprintfGroup(t *testing.T) {
+	testCase := []struct {
+		f Format
+		s string
+	}{
+		{f: TEXT, s: "[INFO] hello http.method=GET\n"},
+		{f: JSON, s: `"msg":"hello","http":{"method":"GET"}}` + "\n"},
+	}
+	for _, tst := range testCase {
+		buf := &bytes.Buffer{}
+		l := New(WithWriter(&bytes.Buffer{}), WithFlags(Llevel), WithFormat(tst.f)).WithGroup("http").WithFields(Fields{"method": "GET"})
+		l.Fprintf(buf, INFO, "hello")
+		if s := buf.String(); !strings.HasSuffix(s, tst.s) {
+			t.Errorf("Fprintf() in %v = %q, want suffix %q.", tst.f, s, tst.s)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,110 @@
+package logf
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+//GELFFormatter is Formatter for GELF 1.1 (Graylog Extended Log Format, one object per line).
+//Level is rendered as syslog severity and structured fields as additional fields ("_"-prefixed).
+type GELFFormatter struct {
+	Host string // host name (host field or os.Hostname() if empty)
+}
+
+var _ FieldFormatter = GELFFormatter{}
+
+var (
+	gelfHostOnce sync.Once
+	gelfHost     string
+)
+
+//Format is method of Formatter interface.
+func (f GELFFormatter) Format(lv Level, prefix string, t time.Time, msg string) []byte {
+	return f.FormatFields(lv, prefix, t, msg, nil)
+}
+
+//FormatFields is method of FieldFormatter interface.
+func (f GELFFormatter) FormatFields(lv Level, prefix string, t time.Time, msg string, fields []Field) []byte {
+	host := f.Host
+	extra := make([]Field, 0, len(fields)+1)
+	if len(prefix) > 0 {
+		extra = append(extra, Field{Key: "prefix", Value: prefix})
+	}
+	for _, fld := range flattenFields(fields) {
+		if fld.Key == "host" && len(host) == 0 {
+			host = logfmtString(fld.Value)
+			continue
+		}
+		extra = append(extra, fld)
+	}
+	if len(host) == 0 {
+		host = defaultGELFHost()
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString(`{"version":"1.1","host":`)
+	buf.Write(jsonValue(host))
+	buf.WriteString(`,"short_message":`)
+	buf.Write(jsonValue(msg))
+	buf.WriteString(`,"timestamp":`)
+	buf.WriteString(strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', 3, 64))
+	buf.WriteString(`,"level":`)
+	buf.WriteString(strconv.Itoa(lv.syslogSeverity()))
+	for _, fld := range extra {
+		buf.WriteByte(',')
+		buf.Write(jsonValue(gelfKey(fld.Key)))
+		buf.WriteByte(':')
+		buf.Write(jsonValue(fld.Value))
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+//gelfKey returns additional field name of GELF ("_"-prefixed, invalid characters are replaced with "_").
+//"_id" is reserved, so "id" key is renamed to "__id".
+func gelfKey(key string) string {
+	b := make([]byte, 0, len(key)+2)
+	b = append(b, '_')
+	if key == "id" {
+		b = append(b, '_')
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '_', c == '.', c == '-':
+			b = append(b, c)
+		default:
+			b = append(b, '_')
+		}
+	}
+	return string(b)
+}
+
+//defaultGELFHost returns cached host name.
+func defaultGELFHost() string {
+	gelfHostOnce.Do(func() {
+		host, err := os.Hostname()
+		if err != nil || len(host) == 0 {
+			host = "unknown"
+		}
+		gelfHost = host
+	})
+	return gelfHost
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,80 @@
+package logf
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGELFFormatter(t *testing.T) {
+	tm := time.Date(2009, time.November, 10, 23, 0, 0, 123000000, time.UTC)
+	fields := []Field{{Key: "host", Value: "web1"}, {Key: "id", Value: 7}, {Key: "user name", Value: "alice"}}
+	s := string(GELFFormatter{}.FormatFields(WARN, "[app] ", tm, "disk full", fields))
+	res := `{"version":"1.1","host":"web1","short_message":"disk full","timestamp":1257894000.123,"level":4,"_prefix":"[app] ","__id":7,"_user_name":"alice"}` + "\n"
+	if s != res {
+		t.Errorf("GELFFormatter.FormatFields()  = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestGELFSchema(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFormat(GELF))
+	l.WithFields(Fields{"req": "abc"}).Error("failed")
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &obj); err != nil {
+		t.Fatalf("GELF output = \"%v\", not JSON: %v", buf.String(), err)
+	}
+	for key, kind := range map[string]string{"version": "string", "host": "string", "short_message": "string", "timestamp": "number", "level": "number", "_req": "string"} {
+		v, ok := obj[key]
+		switch {
+		case !ok:
+			t.Errorf("GELF output has no \"%s\" field: %v", key, obj)
+		case kind == "string":
+			if _, ok := v.(string); !ok {
+				t.Errorf("GELF \"%s\" field = %v, want string.", key, v)
+			}
+		case kind == "number":
+			if _, ok := v.(float64); !ok {
+				t.Errorf("GELF \"%s\" field = %v, want number.", key, v)
+			}
+		}
+	}
+	if obj["version"] != "1.1" || obj["level"] != float64(3) || obj["short_message"] != "failed" {
+		t.Errorf("GELF output = %v, want version 1.1, level 3 and short_message.", obj)
+	}
+}
+
+func TestWithFormat(t *testing.T) {
+	testCase := []struct {
+		f   Format
+		typ Formatter
+	}{
+		{f: TEXT, typ: nil},
+		{f: JSON, typ: JSONFormatter{}},
+		{f: LOGFMT, typ: LogfmtFormatter{}},
+		{f: GELF, typ: GELFFormatter{}},
+	}
+	for _, tst := range testCase {
+		l := New(WithFormatter(testFormatter{}), WithFormat(tst.f))
+		if reflect.TypeOf(l.formatter) != reflect.TypeOf(tst.typ) {
+			t.Errorf("WithFormat(%v) formatter = %T, want %T.", tst.f, l.formatter, tst.typ)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -1 +1,5 @@
 module github.com/spiegel-im-spiegel/logf
+
+go 1.17
+
+require golang.org/x/sys v0.0.0-20190801041406-cbf593c0f2f3
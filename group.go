@@ -0,0 +1,118 @@
+package logf
+
+import (
+	"bytes"
+	"strings"
+)
+
+//groupSep terminates each group name in keys of grouped fields (until grouped by groupFields function).
+const groupSep = "\x00"
+
+//fieldGroup is value of structured field grouping other fields (WithGroup method).
+//It is rendered as nested object in JSON format and as dotted keys (e.g. "http.method") in other formats.
+type fieldGroup []Field
+
+//WithGroup returns child logger which puts structured fields added by it under group of name,
+//like groups of log/slog package (e.g. {"http":{"method":"GET"}} in JSON format, http.method=GET in text format).
+//Fields of parent logger are not changed. Groups of nested child loggers compose.
+func (l *Logger) WithGroup(name string) *Logger {
+	child := l.with()
+	if len(name) > 0 {
+		child.group += name + groupSep
+	}
+	return child
+}
+
+//WithGroup returns child logger of std which puts structured fields under group of name.
+func WithGroup(name string) *Logger { return std.WithGroup(name) }
+
+//groupFields returns fields with grouped ones (keys with group names) collected into fieldGroup values.
+func groupFields(fields []Field) []Field {
+	grouped := false
+	for _, fld := range fields {
+		if strings.Contains(fld.Key, groupSep) {
+			grouped = true
+			break
+		}
+	}
+	if !grouped {
+		return fields
+	}
+	res := make([]Field, 0, len(fields))
+	index := map[string]int{}
+	subs := map[string][]Field{}
+	for _, fld := range fields {
+		i := strings.Index(fld.Key, groupSep)
+		if i < 0 {
+			res = append(res, fld)
+			continue
+		}
+		name := fld.Key[:i]
+		if _, ok := index[name]; !ok {
+			index[name] = len(res)
+			res = append(res, Field{Key: name})
+		}
+		subs[name] = append(subs[name], Field{Key: fld.Key[i+len(groupSep):], Value: fld.Value})
+	}
+	for name, i := range index {
+		res[i].Value = fieldGroup(groupFields(subs[name]))
+	}
+	return res
+}
+
+//flattenFields returns fields with fieldGroup values expanded into fields of dotted keys.
+func flattenFields(fields []Field) []Field {
+	flat := true
+	for _, fld := range fields {
+		if _, ok := fld.Value.(fieldGroup); ok {
+			flat = false
+			break
+		}
+	}
+	if flat {
+		return fields
+	}
+	res := make([]Field, 0, len(fields))
+	for _, fld := range fields {
+		g, ok := fld.Value.(fieldGroup)
+		if !ok {
+			res = append(res, fld)
+			continue
+		}
+		for _, sub := range flattenFields(g) {
+			res = append(res, Field{Key: fld.Key + "." + sub.Key, Value: sub.Value})
+		}
+	}
+	return res
+}
+
+//jsonGroup returns JSON object of fields in g.
+func jsonGroup(g fieldGroup) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, fld := range g {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(jsonValue(fld.Key))
+		buf.WriteByte(':')
+		buf.Write(jsonValue(fld.Value))
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,65 @@
+package logf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWithGroup(t *testing.T) {
+	testCase := []struct {
+		f Format
+		s string
+	}{
+		{f: JSON, s: `"msg":"served","app":"web","http":{"method":"GET","status":200,"req":{"id":7,"path":"/"}}}`},
+		{f: LOGFMT, s: `msg=served app=web http.method=GET http.status=200 http.req.id=7 http.req.path=/`},
+		{f: TEXT, s: `served app=web http.method=GET http.status=200 http.req.id=7 http.req.path=/`},
+	}
+	for _, tst := range testCase {
+		buf := new(bytes.Buffer)
+		l := New(WithWriter(buf), WithFlags(Llevel), WithFormat(tst.f)).WithFields(Fields{"app": "web"})
+		h := l.WithGroup("http").WithFields(Fields{"method": "GET"})
+		h.WithFields(Fields{"status": 200}).WithGroup("req").WithFields(Fields{"id": 7}).Infow("served", Fields{"path": "/"})
+		if s := strings.TrimSuffix(buf.String(), "\n"); !strings.HasSuffix(s, tst.s) {
+			t.Errorf("WithGroup() in %v = \"%v\", want suffix \"%v\".", tst.f, s, tst.s)
+		}
+	}
+}
+
+func TestWithGroupParent(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFormat(JSON))
+	g := l.WithGroup("db")
+	g.Infow("query", Fields{"rows": 1})
+	l.Infow("done", Fields{"rows": 2})
+	g.Print("empty")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	testCase := []string{
+		`"msg":"query","db":{"rows":1}}`,
+		`"msg":"done","rows":2}`,
+		`"msg":"empty"}`,
+	}
+	if len(lines) != len(testCase) {
+		t.Fatalf("WithGroup() output = \"%v\", want %d lines.", buf.String(), len(testCase))
+	}
+	for i, res := range testCase {
+		if !strings.HasSuffix(lines[i], res) {
+			t.Errorf("WithGroup() output = \"%v\", want suffix \"%v\".", lines[i], res)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,65 @@
+package logf
+
+//hook is function called for each logging event at its minimum level or higher.
+type hook struct {
+	min Level
+	fn  func(lv Level, msg string, fields []Field)
+}
+
+//WithHook returns function for adding hook (e.g. for metrics) called for each logging event at level min or higher.
+//Hooks see events filtered only by minimum level of the logger (not by WithMinLevelForWriter option).
+//Fields must not be modified by fn.
+func WithHook(min Level, fn func(lv Level, msg string, fields []Field)) OptFunc {
+	return func(l *Logger) {
+		if fn == nil {
+			return
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.hooks = append(l.hooks, hook{min: min, fn: fn})
+	}
+}
+
+//WithMinLevelForWriter returns function for setting minimum level of events written to the output,
+//separately from minimum level of the logger (which is seen by hooks and sinks).
+func WithMinLevelForWriter(lv Level) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.writerMin = lv
+	}
+}
+
+//callHooks calls hooks for event at level lv.
+func (l *Logger) callHooks(lv Level, msg string, fields []Field) {
+	l.mu.Lock()
+	hooks := l.hooks
+	l.mu.Unlock()
+	for _, h := range hooks {
+		if lv.GTE(h.min) {
+			h.fn(lv, msg, fields)
+		}
+	}
+}
+
+//writable returns true if event at level lv is written to the output.
+func (l *Logger) writable(lv Level) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return lv.GTE(l.writerMin)
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,47 @@
+package logf
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestWithMinLevelForWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var got []string
+	l := New(
+		WithWriter(buf),
+		WithFlags(Llevel),
+		WithMinLevel(INFO),
+		WithMinLevelForWriter(FATAL),
+		WithHook(ERROR, func(lv Level, msg string, fields []Field) {
+			got = append(got, lv.String()+":"+msg+textFields(fields))
+		}),
+	)
+	l.Output(DEBUG, 2, "debug")
+	l.Output(WARN, 2, "warn")
+	l.Infow("info", nil)
+	l.Errorw("disk full", Fields{"id": 1})
+	l.Output(FATAL, 2, "crash")
+	if s, want := buf.String(), "[FATAL] crash\n"; s != want {
+		t.Errorf("output = \"%v\", want \"%v\".", s, want)
+	}
+	if want := []string{"ERROR:disk full id=1", "FATAL:crash"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("hook = %v, want %v.", got, want)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,118 @@
+package logf
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"time"
+)
+
+//statusRecorder is http.ResponseWriter which records status code and size of response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+//WriteHeader is method of http.ResponseWriter interface.
+func (w *statusRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+//Write is method of http.ResponseWriter interface.
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+//Flush is method of http.Flusher interface (no-op if the original writer is not http.Flusher).
+func (w *statusRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+//Hijack is method of http.Hijacker interface (http.ErrNotSupported if the original writer is not http.Hijacker).
+//Status of hijacked connection is recorded as 101 (Switching Protocols) unless written before.
+func (w *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil && w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+//Unwrap returns the original writer (for http.ResponseController).
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+//HTTPMiddleware returns http.Handler which logs each request served by next:
+//"request" message with method, path, status, bytes and duration (in milliseconds) fields.
+//Level is chosen by status: ERROR for 5xx, WARN for 4xx, INFO for others. Clock of WithClock option is used if set.
+func (l *Logger) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.mu.Lock()
+		start := l.clock()
+		l.mu.Unlock()
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		l.mu.Lock()
+		d := l.clock().Sub(start)
+		l.mu.Unlock()
+		fields := []Field{
+			{Key: "method", Value: r.Method},
+			{Key: "path", Value: r.URL.Path},
+			{Key: "status", Value: rec.status},
+			{Key: "bytes", Value: rec.bytes},
+			{Key: "duration", Value: float64(d) / float64(time.Millisecond)},
+		}
+		_ = l.output(statusLevel(rec.status), 2, "request", fields)
+	})
+}
+
+//HTTPMiddleware returns http.Handler which logs each request served by next to std.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return std.HTTPMiddleware(next)
+}
+
+//statusLevel returns Level of HTTP status code.
+func statusLevel(status int) Level {
+	switch {
+	case status >= 500:
+		return ERROR
+	case status >= 400:
+		return WARN
+	default:
+		return INFO
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,140 @@
+package logf
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	testCase := []struct {
+		status int
+		body   string
+		level  string
+	}{
+		{status: 0, body: "hello", level: "INFO"},
+		{status: http.StatusNotFound, body: "not found", level: "WARN"},
+		{status: http.StatusInternalServerError, body: "", level: "ERROR"},
+	}
+	for _, tst := range testCase {
+		buf := new(bytes.Buffer)
+		l := New(WithWriter(buf), WithFormat(JSON))
+		status, body := tst.status, tst.body
+		h := l.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if status != 0 {
+				w.WriteHeader(status)
+			}
+			_, _ = w.Write([]byte(body))
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/items?id=1", nil))
+
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal(buf.Bytes(), &obj); err != nil {
+			t.Fatalf("HTTPMiddleware() output = \"%v\", not JSON: %v", buf.String(), err)
+		}
+		want := status
+		if want == 0 {
+			want = http.StatusOK
+		}
+		if rec.Code != want {
+			t.Errorf("response status = %d, want %d.", rec.Code, want)
+		}
+		if obj["level"] != tst.level || obj["msg"] != "request" || obj["method"] != "GET" || obj["path"] != "/items" || obj["status"] != float64(want) || obj["bytes"] != float64(len(body)) {
+			t.Errorf("HTTPMiddleware() output = %v, want level %s, method GET, path /items, status %d and bytes %d.", obj, tst.level, want, len(body))
+		}
+		if _, ok := obj["duration"].(float64); !ok {
+			t.Errorf("HTTPMiddleware() duration = %v, want number.", obj["duration"])
+		}
+	}
+}
+
+func TestHTTPMiddlewareHijack(t *testing.T) {
+	buf := new(bytes.Buffer)
+	done := make(chan struct{})
+	l := New(WithWriterFunc(func(p []byte) (int, error) {
+		defer close(done)
+		return buf.Write(p)
+	}), WithFormat(JSON))
+	srv := httptest.NewServer(l.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, ok := w.(interface{ Unwrap() http.ResponseWriter }); !ok || u.Unwrap() == nil {
+			t.Error("ResponseWriter of HTTPMiddleware() does not unwrap.")
+		}
+		h, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("ResponseWriter of HTTPMiddleware() is not http.Hijacker.")
+		}
+		conn, rw, err := h.Hijack()
+		if err != nil {
+			t.Fatalf("Hijack() = \"%v\", want nil.", err)
+		}
+		defer conn.Close()
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n")
+		_ = rw.Flush()
+	})))
+	defer srv.Close()
+	req, _ := http.NewRequest("GET", srv.URL+"/ws", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "test")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request = \"%v\", want nil.", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Errorf("response status = %d, want %d.", resp.StatusCode, http.StatusSwitchingProtocols)
+	}
+	<-done
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &obj); err != nil {
+		t.Fatalf("HTTPMiddleware() output = \"%v\", not JSON: %v", buf.String(), err)
+	}
+	if obj["path"] != "/ws" || obj["status"] != float64(http.StatusSwitchingProtocols) {
+		t.Errorf("HTTPMiddleware() output = %v, want path /ws and status 101.", obj)
+	}
+}
+
+func TestHTTPMiddlewareHijackNotSupported(t *testing.T) {
+	l := New(WithWriter(new(bytes.Buffer)), WithFormat(JSON))
+	h := l.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := w.(http.Hijacker).Hijack(); err != http.ErrNotSupported {
+			t.Errorf("Hijack() = \"%v\", want \"%v\".", err, http.ErrNotSupported)
+		}
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
+
+func TestHTTPMiddlewareClock(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFormat(JSON), WithClock(func() time.Time { return now }))
+	h := l.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now = now.Add(1500 * time.Microsecond)
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &obj); err != nil {
+		t.Fatalf("HTTPMiddleware() output = \"%v\", not JSON: %v", buf.String(), err)
+	}
+	if obj["duration"] != 1.5 {
+		t.Errorf("HTTPMiddleware() duration = %v, want 1.5.", obj["duration"])
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,45 @@
+package logf
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+)
+
+//WithInstanceID returns function for attaching "instance_id" field with random UUID-like token
+//(e.g. "0f8fad5b-d9cb-469f-a165-70867728950e") generated at construction.
+//It groups events of one component instance; child loggers inherit it.
+func WithInstanceID() OptFunc {
+	return func(l *Logger) {
+		l.fields = l.fields.push([]Field{{Key: "instance_id", Value: newInstanceID()}})
+	}
+}
+
+//newInstanceID returns random token in form of UUID version 4.
+func newInstanceID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		t := uint64(time.Now().UnixNano())
+		for i := range b {
+			b[i] = byte(t >> (uint(i%8) * 8))
+		}
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
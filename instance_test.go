@@ -0,0 +1,50 @@
+package logf
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func TestWithInstanceID(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(0), WithInstanceID())
+	l.Print("first")
+	l.Print("second")
+	l.WithFields(Fields{"req": 1}).Print("child")
+	l.WithFieldPrefix("db.").Print("grandchild")
+	re := regexp.MustCompile(`^(first|second|child|grandchild) instance_id=([0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12})( req=1)?$`)
+	ids := map[string]bool{}
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		m := re.FindSubmatch(line)
+		if m == nil {
+			t.Errorf("WithInstanceID() output = \"%s\", want instance_id field.", line)
+			continue
+		}
+		ids[string(m[2])] = true
+	}
+	if len(ids) != 1 {
+		t.Errorf("WithInstanceID() ids = %v, want one stable id.", ids)
+	}
+
+	buf2 := new(bytes.Buffer)
+	New(WithWriter(buf2), WithFlags(0), WithInstanceID()).Print("first")
+	if m := re.FindSubmatch(bytes.TrimSpace(buf2.Bytes())); m == nil || ids[string(m[2])] {
+		t.Errorf("WithInstanceID() output of another logger = \"%s\", want different id.", buf2.String())
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,122 @@
+//go:build linux
+// +build linux
+
+package logf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//journalSocket is path of journald native protocol socket.
+var journalSocket = "/run/systemd/journal/socket"
+
+//journaldWriter writes logging events to journald by native protocol.
+type journaldWriter struct {
+	conn  *net.UnixConn
+	ident string
+}
+
+var _ LevelWriter = (*journaldWriter)(nil)
+
+//Write is method of io.Writer interface (as INFO level).
+func (w *journaldWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(INFO, p)
+}
+
+//WriteLevel is method of LevelWriter interface.
+func (w *journaldWriter) WriteLevel(lv Level, p []byte) (int, error) {
+	if err := w.writeEvent(lv, string(p), nil); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+//writeEvent is method of eventWriter interface.
+//Structured fields are sent as journal fields (KEY=VALUE).
+func (w *journaldWriter) writeEvent(lv Level, msg string, fields []Field) error {
+	buf := &bytes.Buffer{}
+	writeJournalField(buf, "PRIORITY", strconv.Itoa(lv.syslogSeverity()))
+	writeJournalField(buf, "SYSLOG_IDENTIFIER", w.ident)
+	writeJournalField(buf, "MESSAGE", strings.TrimSuffix(msg, "\n"))
+	for _, fld := range fields {
+		if key := journalKey(fld.Key); len(key) > 0 {
+			writeJournalField(buf, key, logfmtString(fld.Value))
+		}
+	}
+	_, err := w.conn.Write(buf.Bytes())
+	return err
+}
+
+//Close closes connection to journald.
+func (w *journaldWriter) Close() error {
+	return w.conn.Close()
+}
+
+//writeJournalField writes a field in journald native protocol.
+func writeJournalField(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+//journalKey returns field name for journald (upper case letters, digits and underscores).
+//It is prefixed with "F_" if it starts with a digit.
+func journalKey(key string) string {
+	key = strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z':
+			return r - 'a' + 'A'
+		case 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	key = strings.TrimLeft(key, "_")
+	if len(key) > 0 && '0' <= key[0] && key[0] <= '9' {
+		key = "F_" + key
+	}
+	return key
+}
+
+//WithJournald returns function for writing to journald (systemd journal).
+//Level is sent as PRIORITY field and structured fields as journal fields.
+func WithJournald() (OptFunc, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journalSocket, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	w := &journaldWriter{conn: conn, ident: filepath.Base(os.Args[0])}
+	return func(l *Logger) {
+		l.SetOutput(w)
+	}, nil
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,102 @@
+//go:build linux
+// +build linux
+
+package logf
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJournald(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: sock, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("cannot listen unixgram socket: %v", err)
+	}
+	defer conn.Close()
+	journalSocketBak := journalSocket
+	journalSocket = sock
+	defer func() { journalSocket = journalSocketBak }()
+
+	opt, err := WithJournald()
+	if err != nil {
+		t.Fatalf("WithJournald() = \"%v\", want nil.", err)
+	}
+	l := New(opt, WithPID(), WithPrefix("[TEST] "))
+	testCase := []struct {
+		l Level
+		m string
+		s string
+	}{
+		{l: DEBUG, m: "Debugging", s: "PRIORITY=7\n"},
+		{l: INFO, m: "Information", s: "PRIORITY=6\n"},
+		{l: WARN, m: "Warning", s: "PRIORITY=4\n"},
+		{l: ERROR, m: "Erroring", s: "PRIORITY=3\n"},
+		{l: FATAL, m: "Fatal Erroring", s: "PRIORITY=2\n"},
+	}
+	for _, tst := range testCase {
+		if err := l.Output(tst.l, 2, tst.m); err != nil {
+			t.Errorf("Logger.Output() = \"%v\", want nil.", err)
+		}
+		buf := make([]byte, 4096)
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatalf("Read() = \"%v\", want nil.", err)
+		}
+		res := tst.s + "SYSLOG_IDENTIFIER=" + filepath.Base(os.Args[0]) + "\n" + "MESSAGE=[TEST] " + tst.m + "\n" + fmt.Sprintf("PID=%d\n", os.Getpid())
+		if s := string(buf[:n]); s != res {
+			t.Errorf("journal datagram = \"%v\", want \"%v\".", s, res)
+		}
+	}
+}
+
+func TestJournalField(t *testing.T) {
+	testCase := []struct {
+		key   string
+		value string
+		s     []byte
+	}{
+		{key: "MESSAGE", value: "hello", s: []byte("MESSAGE=hello\n")},
+		{key: "MESSAGE", value: "a\nb", s: append(append([]byte("MESSAGE\n"), 3, 0, 0, 0, 0, 0, 0, 0), []byte("a\nb\n")...)},
+	}
+	for _, tst := range testCase {
+		buf := &bytes.Buffer{}
+		writeJournalField(buf, tst.key, tst.value)
+		if !bytes.Equal(buf.Bytes(), tst.s) {
+			t.Errorf("writeJournalField() = %q, want %q.", buf.Bytes(), tst.s)
+		}
+	}
+	for key, res := range map[string]string{"pid": "PID", "user.name": "USER_NAME", "_private": "PRIVATE", "2fa": "F_2FA", "_1st": "F_1ST"} {
+		if s := journalKey(key); s != res {
+			t.Errorf("journalKey(\"%s\") = \"%v\", want \"%v\".", key, s, res)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -1,5 +1,10 @@
 package logf
 
+import (
+	"sort"
+	"sync/atomic"
+)
+
 //Level is log level
 type Level int
 
@@ -29,6 +34,95 @@ func (lv Level) String() string {
 	return ""
 }
 
+//Short returns single letter name of lv ("T", "D", "I", "W", "E" or "F"; empty if unknown level).
+func (lv Level) Short() string {
+	if s := lv.String(); len(s) > 0 {
+		return s[:1]
+	}
+	return ""
+}
+
+//syslogSeverities maps Level to syslog severity (RFC 5424).
+var syslogSeverities = map[Level]int{
+	TRACE: 7, //debug
+	DEBUG: 7, //debug
+	INFO:  6, //info
+	WARN:  4, //warning
+	ERROR: 3, //err
+	FATAL: 2, //crit
+}
+
+//syslogSeverity returns syslog severity of lv (crit if undefined).
+func (lv Level) syslogSeverity() int {
+	if sev, ok := syslogSeverities[lv]; ok {
+		return sev
+	}
+	return syslogSeverities[FATAL]
+}
+
+//Valid reports whether lv is a defined level.
+func (lv Level) Valid() bool {
+	_, ok := lavelMap[lv]
+	return ok
+}
+
+//Above returns true if lv is more severe than other.
+func (lv Level) Above(other Level) bool {
+	return lv > other
+}
+
+//Below returns true if lv is more verbose than other.
+func (lv Level) Below(other Level) bool {
+	return lv < other
+}
+
+//GTE returns true if lv is min or more severe than min (that is, lv passes minimum level min).
+func (lv Level) GTE(min Level) bool {
+	return lv >= min
+}
+
+//Clamp returns lv limited to range from min to max.
+func (lv Level) Clamp(min, max Level) Level {
+	switch {
+	case lv.Below(min):
+		return min
+	case lv.Above(max):
+		return max
+	default:
+		return lv
+	}
+}
+
+//Levels returns all defined levels in ascending order.
+func Levels() []Level {
+	lvs := make([]Level, 0, len(lavelMap))
+	for lv := range lavelMap {
+		lvs = append(lvs, lv)
+	}
+	sort.Slice(lvs, func(i, j int) bool { return lvs[i] < lvs[j] })
+	return lvs
+}
+
+//LevelVar is a Level variable, to allow a Logger level to change dynamically.
+//It is safe for concurrent use. The zero LevelVar corresponds to TRACE.
+type LevelVar struct {
+	val int64
+}
+
+//Get returns the level.
+func (v *LevelVar) Get() Level {
+	return Level(atomic.LoadInt64(&v.val))
+}
+
+//Set sets the level.
+func (v *LevelVar) Set(lv Level) {
+	atomic.StoreInt64(&v.val, int64(lv))
+}
+
+func (v *LevelVar) String() string {
+	return v.Get().String()
+}
+
 /* Copyright 2018 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
@@ -1,6 +1,9 @@
 package logf
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestLevelString(t *testing.T) {
 	testCase := []struct {
@@ -22,6 +25,135 @@ func TestLevelString(t *testing.T) {
 	}
 }
 
+func TestLevelVar(t *testing.T) {
+	v := &LevelVar{}
+	if v.Get() != TRACE {
+		t.Errorf("LevelVar.Get()  = %v, want %v.", v.Get(), TRACE)
+	}
+	for _, lv := range []Level{DEBUG, INFO, WARN, ERROR, FATAL, TRACE} {
+		v.Set(lv)
+		if v.Get() != lv {
+			t.Errorf("LevelVar.Get()  = %v, want %v.", v.Get(), lv)
+		}
+		if v.String() != lv.String() {
+			t.Errorf("LevelVar.String()  = \"%v\", want \"%v\".", v.String(), lv.String())
+		}
+	}
+}
+
+func TestLevels(t *testing.T) {
+	lvs := Levels()
+	if len(lvs) != len(lavelMap) {
+		t.Errorf("Levels() = %v, want %d levels.", lvs, len(lavelMap))
+	}
+	for i, lv := range lvs {
+		if !lv.Valid() {
+			t.Errorf("Level(%d).Valid() = false, want true.", int(lv))
+		}
+		if i > 0 && lvs[i-1] >= lv {
+			t.Errorf("Levels() = %v, want ascending order.", lvs)
+		}
+	}
+	if lvs[0] != TRACE || lvs[len(lvs)-1] != FATAL {
+		t.Errorf("Levels() = %v, want TRACE..FATAL.", lvs)
+	}
+	for _, lv := range []Level{TRACE - 1, FATAL + 1} {
+		if lv.Valid() {
+			t.Errorf("Level(%d).Valid() = true, want false.", int(lv))
+		}
+	}
+}
+
+func TestWithNumericLevelToken(t *testing.T) {
+	testCase := []struct {
+		l Level
+		s string
+	}{
+		{l: TRACE, s: "[0] hello\n"},
+		{l: DEBUG, s: "[1] hello\n"},
+		{l: INFO, s: "[2] hello\n"},
+		{l: WARN, s: "[3] hello\n"},
+		{l: ERROR, s: "[4] hello\n"},
+		{l: FATAL, s: "[5] hello\n"},
+	}
+	for _, tst := range testCase {
+		buf := &strings.Builder{}
+		l := New(WithWriter(buf), WithFlags(Llevel), WithNumericLevelToken(true))
+		_ = l.Output(tst.l, 2, "hello")
+		if buf.String() != tst.s {
+			t.Errorf("Output(%v)  = \"%v\", want \"%v\".", tst.l, buf.String(), tst.s)
+		}
+	}
+}
+
+func TestLevelCompare(t *testing.T) {
+	testCase := []struct {
+		l, other     Level
+		above, below bool
+	}{
+		{l: INFO, other: DEBUG, above: true, below: false},
+		{l: INFO, other: INFO, above: false, below: false},
+		{l: INFO, other: WARN, above: false, below: true},
+		{l: TRACE, other: FATAL, above: false, below: true},
+	}
+	for _, tst := range testCase {
+		if tst.l.Above(tst.other) != tst.above {
+			t.Errorf("%v.Above(%v)  = %v, want %v.", tst.l, tst.other, !tst.above, tst.above)
+		}
+		if tst.l.Below(tst.other) != tst.below {
+			t.Errorf("%v.Below(%v)  = %v, want %v.", tst.l, tst.other, !tst.below, tst.below)
+		}
+	}
+}
+
+func TestLevelClamp(t *testing.T) {
+	testCase := []struct {
+		l, min, max, res Level
+	}{
+		{l: TRACE, min: DEBUG, max: ERROR, res: DEBUG},
+		{l: DEBUG, min: DEBUG, max: ERROR, res: DEBUG},
+		{l: WARN, min: DEBUG, max: ERROR, res: WARN},
+		{l: ERROR, min: DEBUG, max: ERROR, res: ERROR},
+		{l: FATAL, min: DEBUG, max: ERROR, res: ERROR},
+	}
+	for _, tst := range testCase {
+		if res := tst.l.Clamp(tst.min, tst.max); res != tst.res {
+			t.Errorf("%v.Clamp(%v, %v)  = %v, want %v.", tst.l, tst.min, tst.max, res, tst.res)
+		}
+	}
+}
+
+func TestLevelShort(t *testing.T) {
+	testCase := []struct {
+		l Level
+		s string
+	}{
+		{l: TRACE, s: "T"},
+		{l: DEBUG, s: "D"},
+		{l: INFO, s: "I"},
+		{l: WARN, s: "W"},
+		{l: ERROR, s: "E"},
+		{l: FATAL, s: "F"},
+		{l: FATAL + 1, s: ""},
+	}
+	for _, tst := range testCase {
+		if tst.l.Short() != tst.s {
+			t.Errorf("Level(%d).Short()  = \"%v\", want \"%v\".", int(tst.l), tst.l.Short(), tst.s)
+		}
+	}
+}
+
+func TestLevelGTE(t *testing.T) {
+	levels := []Level{TRACE, DEBUG, INFO, WARN, ERROR, FATAL}
+	for i, lv := range levels {
+		for j, min := range levels {
+			if got, want := lv.GTE(min), i >= j; got != want {
+				t.Errorf("%v.GTE(%v) = %v, want %v.", lv, min, got, want)
+			}
+		}
+	}
+}
+
 /* Copyright 2018 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
@@ -5,7 +5,10 @@ import (
 	"io"
 	"log"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // These flags define which text to prefix to each log entry generated by the Logger (compatible with log package).
@@ -22,12 +25,85 @@ const (
 
 const maskStdLogFlags = Ldate | Ltime | Lmicroseconds | Llongfile | Lshortfile | LUTC
 
+//defaultCallDepth is calldepth of print functions (Print, Printf, ...) from log.Logger.Output().
+const defaultCallDepth = 4
+
 //Logger is logger class
 type Logger struct {
-	lg   *log.Logger // logger
-	mu   sync.Mutex  // ensures atomic writes; protects the following fields
-	flag int         // properties
-	min  Level       // minimum level for filtering
+	*core                 // configuration and output (shared with child loggers)
+	fields    *fieldChain // structured fields of the logger (chained to fields of parent logger)
+	reqLevel  *Level      // minimum level of request bound by WithContext method (nil if not bound)
+	keyPrefix string      // prefix of structured field keys bound by WithFieldPrefix method
+	group     string      // path of groups bound by WithGroup method (each name is terminated by groupSep)
+	tees      []*Logger   // secondary loggers receiving the same events (copied on write; protected by mu of core)
+}
+
+//core is configuration and output of Logger
+type core struct {
+	filtered      uint64                                                // count of filtered events (accessed atomically; first for 64-bit alignment)
+	dropped       uint64                                                // count of events dropped while paused (accessed atomically; second for 64-bit alignment)
+	suppressed    uint64                                                // count of events dropped by field filter (accessed atomically; third for 64-bit alignment)
+	paused        int32                                                 // paused if not 0 (accessed atomically)
+	lg            *log.Logger                                           // logger
+	mu            sync.Mutex                                            // ensures atomic writes; protects the following fields
+	flag          int                                                   // logf-specific properties (others are kept by log.Logger)
+	min           Level                                                 // minimum level for filtering
+	minVar        *LevelVar                                             // minimum level for filtering (overrides min if not nil)
+	host          string                                                // cached host name (empty if not emitted)
+	pid           int                                                   // cached process ID (0 if not emitted)
+	mem           *ringBuffer                                           // recent log lines (nil if disabled)
+	formatter     Formatter                                             // formatter for output (nil if standard text format)
+	errHandler    func(error)                                           // handler of internal errors
+	color         ColorMode                                             // color mode of level token
+	levelColors   map[Level]string                                      // custom colors of level token
+	colorFrom     Level                                                 // minimum level of colorized level token
+	fatalHooks    []func(string)                                        // hooks called by Fatal* functions
+	exit          func(int)                                             // exit function called by Fatal* functions (nil if not exit)
+	errType       bool                                                  // emit type name of error field
+	depth         int                                                   // calldepth of print functions (for file name and line number)
+	multiline     bool                                                  // put prefix on each line of multi-line message
+	prefixFunc    func(Level) string                                    // prefix by level (overrides static prefix if not nil)
+	owned         []io.Closer                                           // writers owned by the logger (closed by Close method)
+	callerLevel   *Level                                                // minimum level of events with caller (nil if by flags)
+	maxFields     int                                                   // max number of structured fields (0 is unlimited)
+	transform     func(string, interface{}) (string, interface{}, bool) // transformer of fields (nil if not set)
+	sampler       *sampler                                              // sampler of events (nil if not sampled)
+	bursts        *burstState                                           // state of burst summary (nil if disabled)
+	version       string                                                // application version (empty if not emitted)
+	commit        string                                                // application commit (empty if not emitted)
+	now           func() time.Time                                      // clock of time stamp (nil if time.Now)
+	compact       bool                                                  // print date only when it changes
+	lastDate      string                                                // date of last event (for compact time)
+	raw           bool                                                  // write message verbatim
+	sinks         []*sink                                               // additional outputs
+	numericLevel  bool                                                  // print level token as integer
+	flushLevel    *Level                                                // minimum level of events flushing the writer (nil if not flushed)
+	missingArg    func(string, int)                                     // handler of missing format arguments (nil if not set)
+	assertPanic   bool                                                  // panic on failed assertion
+	chw           *chanWriter                                           // writer of WithChannel option (nil if not set)
+	once          map[string]struct{}                                   // messages printed by WarnOnce method
+	routes        []levelRoute                                          // writers by range of levels
+	file          *os.File                                              // file of WithOutputFile option (for Reopen method)
+	filePerm      os.FileMode                                           // permission of file
+	diag          io.Writer                                             // output of internal errors and warnings (nil if discarded)
+	banner        bool                                                  // print startup banner
+	verboseErr    bool                                                  // emit %+v rendering of error field
+	hooks         []hook                                                // hooks of logging events
+	writerMin     Level                                                 // minimum level of events written to the output
+	relative      bool                                                  // print time stamp relative to start
+	start         time.Time                                             // creation time of the logger (for relative time)
+	levelHook     func(old, new Level)                                  // hook called when the minimum level is changed
+	csvHeader     bool                                                  // write header line of CSV format
+	csvHeaderDone bool                                                  // header line of CSV format has been written
+	singleLine    bool                                                  // escape line terminators of message
+	reservoirs    map[Level]*reservoir                                  // reservoir samplers by level (nil if not sampled)
+	fieldSep      string                                                // separator between pairs of fields (space if empty)
+	kvSep         string                                                // separator between key and value of fields ("=" if empty)
+	deltaTime     bool                                                  // print time since the previous event
+	lastEvent     time.Time                                             // time of the previous event (for delta time)
+	stackLevel    *Level                                                // minimum level of events with stack field (nil if disabled)
+	shared        *sync.Mutex                                           // mutex shared with other loggers for writes (nil if not shared)
+	fieldFilter   func(fields map[string]interface{}) bool              // predicate on fields of events (nil if not filtered)
 }
 
 //OptFunc is self-referential function for functional options pattern
@@ -35,10 +111,15 @@ type OptFunc func(*Logger)
 
 // New creates a new Logger.
 func New(opts ...OptFunc) *Logger {
-	l := &Logger{lg: log.New(os.Stderr, "", LstdFlags&maskStdLogFlags), flag: LstdFlags, min: TRACE}
+	l := &Logger{core: &core{lg: log.New(os.Stderr, "", LstdFlags&maskStdLogFlags), flag: LstdFlags, min: TRACE, exit: os.Exit, depth: defaultCallDepth, diag: os.Stderr}}
 	for _, opt := range opts {
 		opt(l)
 	}
+	if l.relative {
+		l.start = l.clock()
+	}
+	l.checkFlags()
+	l.printBanner(3)
 	return l
 }
 
@@ -51,6 +132,23 @@ func WithWriter(w io.Writer) OptFunc {
 	}
 }
 
+//WriterFunc is an adapter to allow the use of ordinary function as io.Writer.
+type WriterFunc func(p []byte) (int, error)
+
+//Write calls f(p).
+func (f WriterFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+//WithWriterFunc returns function for setting function as Writer
+func WithWriterFunc(f func(p []byte) (int, error)) OptFunc {
+	return func(l *Logger) {
+		if f != nil {
+			l.SetOutput(WriterFunc(f))
+		}
+	}
+}
+
 //WithFlags returns function for setting flags
 func WithFlags(flag int) OptFunc {
 	return func(l *Logger) {
@@ -58,6 +156,56 @@ func WithFlags(flag int) OptFunc {
 	}
 }
 
+//WithBaseCallDepth returns function for setting calldepth of print functions (default 4).
+//Frameworks wrapping the logger can add the number of their own frames to report file name and line number of the callers.
+func WithBaseCallDepth(n int) OptFunc {
+	return func(l *Logger) {
+		if n < 1 {
+			return
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.depth = n
+	}
+}
+
+//WithUTC returns function for setting or clearing LUTC flag.
+//Other flags are kept. Note that LUTC has effect only with Ldate or Ltime flag;
+//New reports ErrUTCWithoutTime to error handler if the time flags are missing.
+func WithUTC(utc bool) OptFunc {
+	return func(l *Logger) {
+		if utc {
+			l.SetFlags(l.Flags() | LUTC)
+		} else {
+			l.SetFlags(l.Flags() &^ LUTC)
+		}
+	}
+}
+
+//WithCaller returns function for emitting file name and line number of caller.
+//It sets Lshortfile flag if caller is true, and clears Lshortfile and Llongfile flags if false.
+//Other flags are kept.
+func WithCaller(caller bool) OptFunc {
+	return func(l *Logger) {
+		if caller {
+			l.SetFlags(l.Flags() | Lshortfile)
+		} else {
+			l.SetFlags(l.Flags() &^ (Lshortfile | Llongfile))
+		}
+	}
+}
+
+//WithCallerOnLevel returns function for emitting file name and line number of caller
+//only for events at level lv or higher. Lshortfile is used unless Llongfile flag is set.
+//It overrides file flags; in structured formats (e.g. WithFormatter option) the caller is emitted as "caller" field.
+func WithCallerOnLevel(lv Level) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.callerLevel = &lv
+	}
+}
+
 //WithPrefix returns function for setting prefix string
 func WithPrefix(prefix string) OptFunc {
 	return func(l *Logger) {
@@ -65,6 +213,17 @@ func WithPrefix(prefix string) OptFunc {
 	}
 }
 
+//WithPrefixFunc returns function for setting prefix by level.
+//The function is used instead of static prefix set by WithPrefix.
+//It is called while the logger is locked, so it must not call the logger.
+func WithPrefixFunc(f func(Level) string) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.prefixFunc = f
+	}
+}
+
 //WithMinLevel returns function for setting minimum level
 func WithMinLevel(lv Level) OptFunc {
 	return func(l *Logger) {
@@ -72,6 +231,49 @@ func WithMinLevel(lv Level) OptFunc {
 	}
 }
 
+//WithMinLevelVar returns function for setting minimum level variable.
+//The variable is read on each logging event, so changing it affects the logger immediately.
+func WithMinLevelVar(v *LevelVar) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.minVar = v
+	}
+}
+
+//WithHostname returns function for emitting host name.
+//The host name is cached at construction; if os.Hostname() fails, "unknown" is used.
+func WithHostname() OptFunc {
+	return func(l *Logger) {
+		host, err := os.Hostname()
+		if err != nil || len(host) == 0 {
+			host = "unknown"
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.host = host
+	}
+}
+
+//WithPID returns function for emitting process ID.
+//The process ID is cached at construction.
+func WithPID() OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.pid = os.Getpid()
+	}
+}
+
+//WithNumericLevelToken returns function for printing level token as integer ("[2]" instead of "[INFO]") in text format.
+func WithNumericLevelToken(numeric bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.numericLevel = numeric
+	}
+}
+
 // SetOutput sets the output destination for the logger.
 func (l *Logger) SetOutput(w io.Writer) {
 	l.mu.Lock()
@@ -79,11 +281,24 @@ func (l *Logger) SetOutput(w io.Writer) {
 	l.lg.SetOutput(w)
 }
 
+// Flags returns the output flags for the logger.
+func (l *Logger) Flags() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.flags()
+}
+
+//flags returns the output flags: logf-specific flags and flags of log.Logger
+//(which may be changed through GetLogger). It must be called with l.mu held.
+func (l *Logger) flags() int {
+	return (l.flag &^ maskStdLogFlags) | (l.lg.Flags() & maskStdLogFlags)
+}
+
 // SetFlags sets the output flags for the logger.
 func (l *Logger) SetFlags(flag int) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	l.flag = flag
+	l.flag = flag &^ maskStdLogFlags
 	l.lg.SetFlags(flag & maskStdLogFlags)
 }
 
@@ -93,64 +308,223 @@ func (l *Logger) SetPrefix(prefix string) {
 }
 
 // SetMinLevel sets the minimum level for the logger.
+// It detaches the level variable set by WithMinLevelVar.
+// The hook set by WithLevelChangeHook is called after the change.
 func (l *Logger) SetMinLevel(lv Level) {
 	l.mu.Lock()
-	defer l.mu.Unlock()
+	old := l.MinLevel()
 	l.min = lv
+	l.minVar = nil
+	hook := l.levelHook
+	l.mu.Unlock()
+	if hook != nil {
+		hook(old, lv)
+	}
+}
+
+//WithLevelChangeHook returns function for setting hook called when the minimum level is changed by SetMinLevel method
+//(e.g. for audit logging of verbosity changes).
+func WithLevelChangeHook(hook func(old, new Level)) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.levelHook = hook
+	}
+}
+
+//Quiet sets the minimum level to ERROR (e.g. for -q option of command-line tools).
+func (l *Logger) Quiet() { l.SetMinLevel(ERROR) }
+
+//Verbose sets the minimum level to DEBUG (e.g. for -v option of command-line tools).
+func (l *Logger) Verbose() { l.SetMinLevel(DEBUG) }
+
+//VeryVerbose sets the minimum level to TRACE (e.g. for -vv option of command-line tools).
+func (l *Logger) VeryVerbose() { l.SetMinLevel(TRACE) }
+
+//Filtered returns count of logging events filtered by minimum level.
+func (l *Logger) Filtered() uint64 {
+	return atomic.LoadUint64(&l.filtered)
 }
 
 // MinLevel returns the minimum level for the logger.
 func (l *Logger) MinLevel() Level {
+	if v := l.minVar; v != nil {
+		return v.Get()
+	}
 	return l.min
 }
 
-//GetLogger returns log.Logger instance
+//GetLogger returns log.Logger instance.
+//Changes of flags, prefix and output through the instance are reflected in the logger.
 func (l *Logger) GetLogger() *log.Logger {
 	return l.lg
 }
 
 //Output writes the output for a logging event.
 func (l *Logger) Output(lv Level, calldepth int, s string) error {
-	if lv >= l.min {
-		if (l.flag & Llevel) != 0 {
-			return l.lg.Output(calldepth, fmt.Sprintf("[%v] %s", lv, s))
+	return l.output(lv, calldepth+1, s, nil)
+}
+
+//output writes the output for a logging event with structured fields.
+//calldepth is the same as l.lg.Output().
+func (l *Logger) output(lv Level, calldepth int, s string, fields []Field) error {
+	return l.outputMerged(lv, calldepth+1, s, l.prefixKeys(fields))
+}
+
+//outputMerged adds structured fields of l to fields of a logging event (already prefixed) and writes the event
+//to l and its tee loggers. Tee loggers receive the merged fields as they are and add only their own fields
+//(lazy fields are evaluated at most once for all of them).
+func (l *Logger) outputMerged(lv Level, calldepth int, s string, fields []Field) error {
+	s = l.escapeLines(s)
+	if n := l.fields.len(); n > 0 {
+		fields = levelFields(lv, append(l.fields.appendTo(make([]Field, 0, n+len(fields))), fields...))
+	}
+	tees := l.teeLoggers()
+	if len(tees) > 0 {
+		fields = shareLazy(fields)
+	}
+	err := l.emit(lv, calldepth+1, s, fields)
+	for _, t := range tees {
+		if e := t.outputMerged(lv, calldepth+1, s, fields); err == nil {
+			err = e
 		}
-		return l.lg.Output(calldepth, s)
 	}
-	return nil
+	return err
 }
 
-//lprintf calls l.Output() to print to the logger.
-//Arguments are handled in the manner of fmt.Printf.
-func (l *Logger) lprintf(lv Level, format string, v ...interface{}) {
-	_ = l.Output(lv, 4, fmt.Sprintf(format, v...))
+//emit filters and writes a logging event to the output of l.
+func (l *Logger) emit(lv Level, calldepth int, s string, fields []Field) error {
+	if l.drop(1) {
+		return nil
+	}
+	if !lv.GTE(l.minLevel()) {
+		atomic.AddUint64(&l.filtered, 1)
+		l.rememberFiltered(lv, s, fields)
+		return nil
+	}
+	if !l.sample(lv, s) {
+		return nil
+	}
+	fields = l.capFields(l.transformFields(groupFields(l.expandErrors(resolveFields(fields)))))
+	if !l.filterFields(fields) {
+		return nil
+	}
+	fields = l.stackFields(lv, calldepth, fields)
+	l.remember(lv, s, fields)
+	l.callHooks(lv, s, fields)
+	if !l.reserve(lv, calldepth+1, s, fields) {
+		return nil
+	}
+	if !l.burst(lv, calldepth+1, s, fields) {
+		return nil
+	}
+	return l.dispatch(lv, calldepth+1, s, fields)
 }
 
-//lprint calls l.Output() to print to the logger.
-//Arguments are handled in the manner of fmt.Print.
-func (l *Logger) lprint(lv Level, v ...interface{}) { _ = l.Output(lv, 4, fmt.Sprint(v...)) }
+//dispatch writes a logging event to the output and sinks of l.
+func (l *Logger) dispatch(lv Level, calldepth int, s string, fields []Field) error {
+	var err error
+	if l.writable(lv) {
+		err = l.writeOutput(lv, calldepth+1, s, fields)
+	}
+	if e := l.writeSinks(lv, calldepth+1, s, fields); err == nil {
+		err = e
+	}
+	if e := l.flushOnLevel(lv); err == nil {
+		err = e
+	}
+	if err != nil {
+		l.handleError(fmt.Errorf("%w: %v", ErrWriteFailed, err))
+	}
+	return err
+}
 
-//lprintln calls l.Output() to print to the logger.
-//Arguments are handled in the manner of fmt.Println.
-func (l *Logger) lprintln(lv Level, v ...interface{}) { _ = l.Output(lv, 4, fmt.Sprintln(v...)) }
+//writeOutput writes a logging event to the output of l.
+func (l *Logger) writeOutput(lv Level, calldepth int, s string, fields []Field) error {
+	if w, ok := l.lg.Writer().(eventWriter); ok {
+		return l.writeEvent(w, lv, s, fields)
+	}
+	if l.formatter != nil {
+		return l.format(lv, calldepth+1, s, fields)
+	}
+	return l.writeText(lv, calldepth+1, s+l.renderFields(fields))
+}
 
-//Tracef calls l.lprintf() to print to the logger.
-func (l *Logger) Tracef(format string, v ...interface{}) { l.lprintf(TRACE, format, v...) }
+//prefix returns the prefix string for level lv.
+//It must be called with l.mu held.
+func (l *Logger) prefix(lv Level) string {
+	if l.prefixFunc != nil {
+		return l.prefixFunc(lv)
+	}
+	return l.lg.Prefix()
+}
 
-//Trace calls l.lprint() to print to the logger.
-func (l *Logger) Trace(v ...interface{}) { l.lprint(TRACE, v...) }
+//header returns the tokens put in front of the message ("[version commit] [host:pid] [LEVEL] ").
+//If color is true, the level token is colorized.
+func (l *Logger) header(lv Level, color bool) string {
+	hd := l.buildTag()
+	switch {
+	case len(l.host) > 0 && l.pid > 0:
+		hd += fmt.Sprintf("[%s:%d] ", l.host, l.pid)
+	case len(l.host) > 0:
+		hd += fmt.Sprintf("[%s] ", l.host)
+	case l.pid > 0:
+		hd += fmt.Sprintf("[%d] ", l.pid)
+	}
+	if (l.flags() & Llevel) != 0 {
+		token := fmt.Sprintf("[%v]", lv)
+		if l.numericLevel {
+			token = fmt.Sprintf("[%d]", int(lv))
+		}
+		if color && lv.GTE(l.colorFrom) {
+			token = colorize(token, l.levelColor(lv))
+		}
+		hd += token + " "
+	}
+	return hd
+}
 
-//Traceln calls l.lprintln() to print to the logger.
-func (l *Logger) Traceln(v ...interface{}) { l.lprintln(TRACE, v...) }
+//lprintf calls l.Output() to print to the logger and returns the message.
+//Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) lprintf(lv Level, format string, v ...interface{}) string {
+	s := l.message(l.sprintf(format, v...))
+	_ = l.Output(lv, l.depth, s)
+	return s
+}
 
-//Debugf calls l.lprintf() to print to the logger.
-func (l *Logger) Debugf(format string, v ...interface{}) { l.lprintf(DEBUG, format, v...) }
+//lprint calls l.Output() to print to the logger and returns the message.
+//Arguments are handled in the manner of fmt.Print.
+func (l *Logger) lprint(lv Level, v ...interface{}) string {
+	s := l.message(sprint(v...))
+	_ = l.Output(lv, l.depth, s)
+	return s
+}
+
+//lprintln calls l.Output() to print to the logger and returns the message.
+//Arguments are handled in the manner of fmt.Println.
+func (l *Logger) lprintln(lv Level, v ...interface{}) string {
+	s := l.message(sprintln(v...))
+	_ = l.Output(lv, l.depth, s)
+	return s
+}
 
-//Debug calls l.lprint() to print to the logger.
-func (l *Logger) Debug(v ...interface{}) { l.lprint(DEBUG, v...) }
+//message returns message of print functions (trailing line terminators are removed unless WithRawMessage option is set).
+func (l *Logger) message(s string) string {
+	l.mu.Lock()
+	raw := l.raw
+	l.mu.Unlock()
+	if raw {
+		return s
+	}
+	return trimNewline(s)
+}
 
-//Debugln calls l.lprintln() to print to the logger.
-func (l *Logger) Debugln(v ...interface{}) { l.lprintln(DEBUG, v...) }
+//trimNewline removes trailing line terminators from message.
+//So every print function writes exactly one line terminator (appended by Output)
+//regardless of trailing newlines in arguments.
+func trimNewline(s string) string {
+	return strings.TrimRight(s, "\r\n")
+}
 
 //Printf calls l.lprintf() to print to the logger.
 func (l *Logger) Printf(format string, v ...interface{}) { l.lprintf(INFO, format, v...) }
@@ -179,33 +553,36 @@ func (l *Logger) Error(v ...interface{}) { l.lprint(ERROR, v...) }
 //Errorln calls l.lprintln() to print to the logger.
 func (l *Logger) Errorln(v ...interface{}) { l.lprintln(ERROR, v...) }
 
-//Fatalf calls l.lprintf() to print to the logger.
-func (l *Logger) Fatalf(format string, v ...interface{}) { l.lprintf(FATAL, format, v...) }
+//Fatalf calls l.lprintf() to print to the logger, followed by fatal hooks and exit function.
+func (l *Logger) Fatalf(format string, v ...interface{}) { l.fatal(l.lprintf(FATAL, format, v...)) }
 
-//Fatal calls l.lprint() to print to the logger.
-func (l *Logger) Fatal(v ...interface{}) { l.lprint(FATAL, v...) }
+//Fatal calls l.lprint() to print to the logger, followed by fatal hooks and exit function.
+func (l *Logger) Fatal(v ...interface{}) { l.fatal(l.lprint(FATAL, v...)) }
 
-//Fatalln calls l.lprintln() to print to the logger.
-func (l *Logger) Fatalln(v ...interface{}) { l.lprintln(FATAL, v...) }
+//Fatalln calls l.lprintln() to print to the logger, followed by fatal hooks and exit function.
+func (l *Logger) Fatalln(v ...interface{}) { l.fatal(l.lprintln(FATAL, v...)) }
 
-//Panicf is equivalent() to l.Output() followed by a call to panic().
+//Panicf is equivalent() to l.Output() followed by a call to panic() (buffered output is flushed before that).
 func (l *Logger) Panicf(format string, v ...interface{}) {
-	s := fmt.Sprintf(format, v...)
-	_ = l.Output(FATAL, 4, s)
+	s := l.sprintf(format, v...)
+	_ = l.Output(FATAL, l.depth-1, trimNewline(s))
+	_ = l.flush()
 	panic(s)
 }
 
-//Panic is equivalent() to l.Output() followed by a call to panic().
+//Panic is equivalent() to l.Output() followed by a call to panic() (buffered output is flushed before that).
 func (l *Logger) Panic(v ...interface{}) {
-	s := fmt.Sprint(v...)
-	_ = l.Output(FATAL, 4, s)
+	s := sprint(v...)
+	_ = l.Output(FATAL, l.depth-1, trimNewline(s))
+	_ = l.flush()
 	panic(s)
 }
 
-//Panicln is equivalent() to l.Output() followed by a call to panic().
+//Panicln is equivalent() to l.Output() followed by a call to panic() (buffered output is flushed before that).
 func (l *Logger) Panicln(v ...interface{}) {
-	s := fmt.Sprintln(v...)
-	_ = l.Output(FATAL, 4, s)
+	s := sprintln(v...)
+	_ = l.Output(FATAL, l.depth-1, trimNewline(s))
+	_ = l.flush()
 	panic(s)
 }
 
@@ -214,6 +591,9 @@ var std = New()
 // SetOutput sets the output destination for the logger.
 func SetOutput(w io.Writer) { std.SetOutput(w) }
 
+// Flags returns the output flags for the logger.
+func Flags() int { return std.Flags() }
+
 // SetFlags sets the output flags for the logger.
 func SetFlags(flag int) { std.SetFlags(flag) }
 
@@ -223,9 +603,21 @@ func SetPrefix(prefix string) { std.SetPrefix(prefix) }
 // SetMinLevel sets the minimum level for the logger.
 func SetMinLevel(lv Level) { std.SetMinLevel(lv) }
 
+//Quiet sets the minimum level of std to ERROR.
+func Quiet() { std.Quiet() }
+
+//Verbose sets the minimum level of std to DEBUG.
+func Verbose() { std.Verbose() }
+
+//VeryVerbose sets the minimum level of std to TRACE.
+func VeryVerbose() { std.VeryVerbose() }
+
 // MinLevel returns the minimum level for the logger.
 func MinLevel() Level { return std.MinLevel() }
 
+//Filtered returns count of logging events filtered by minimum level of std.
+func Filtered() uint64 { return std.Filtered() }
+
 //GetLogger returns log.Logger instance
 func GetLogger() *log.Logger { return std.GetLogger() }
 
@@ -234,24 +626,6 @@ func Output(lv Level, calldepth int, s string) error {
 	return std.Output(lv, calldepth, s)
 }
 
-//Tracef calls std.Tracef() to print to the logger.
-func Tracef(format string, v ...interface{}) { std.lprintf(TRACE, format, v...) }
-
-//Trace calls std.Trace() to print to the logger.
-func Trace(v ...interface{}) { std.lprint(TRACE, v...) }
-
-//Traceln calls std.Traceln() to print to the logger.
-func Traceln(v ...interface{}) { std.lprintln(TRACE, v...) }
-
-//Debugf calls std.Debugf() to print to the logger.
-func Debugf(format string, v ...interface{}) { std.lprintf(DEBUG, format, v...) }
-
-//Debug calls std.Debug() to print to the logger.
-func Debug(v ...interface{}) { std.lprint(DEBUG, v...) }
-
-//Debugln calls std.Debugln() to print to the logger.
-func Debugln(v ...interface{}) { std.lprintln(DEBUG, v...) }
-
 //Printf calls std.Printf() to print to the logger.
 func Printf(format string, v ...interface{}) { std.lprintf(INFO, format, v...) }
 
@@ -280,32 +654,35 @@ func Error(v ...interface{}) { std.lprint(ERROR, v...) }
 func Errorln(v ...interface{}) { std.lprintln(ERROR, v...) }
 
 //Fatalf calls std.Fatalf() to print to the logger.
-func Fatalf(format string, v ...interface{}) { std.lprintf(FATAL, format, v...) }
+func Fatalf(format string, v ...interface{}) { std.fatal(std.lprintf(FATAL, format, v...)) }
 
 //Fatal calls std.Fatal() to print to the logger.
-func Fatal(v ...interface{}) { std.lprint(FATAL, v...) }
+func Fatal(v ...interface{}) { std.fatal(std.lprint(FATAL, v...)) }
 
 //Fatalln calls std.Fatalln() to print to the logger.
-func Fatalln(v ...interface{}) { std.lprintln(FATAL, v...) }
+func Fatalln(v ...interface{}) { std.fatal(std.lprintln(FATAL, v...)) }
 
-//Panicf is equivalent() to std.Output() followed by a call to panic().
+//Panicf is equivalent() to std.Output() followed by a call to panic() (buffered output is flushed before that).
 func Panicf(format string, v ...interface{}) {
-	s := fmt.Sprintf(format, v...)
-	_ = std.Output(FATAL, 4, s)
+	s := std.sprintf(format, v...)
+	_ = std.Output(FATAL, std.depth-1, trimNewline(s))
+	_ = std.flush()
 	panic(s)
 }
 
-//Panic is equivalent() to std.Output() followed by a call to panic().
+//Panic is equivalent() to std.Output() followed by a call to panic() (buffered output is flushed before that).
 func Panic(v ...interface{}) {
-	s := fmt.Sprint(v...)
-	_ = std.Output(FATAL, 4, s)
+	s := sprint(v...)
+	_ = std.Output(FATAL, std.depth-1, trimNewline(s))
+	_ = std.flush()
 	panic(s)
 }
 
-//Panicln is equivalent() to std.Output() followed by a call to panic().
+//Panicln is equivalent() to std.Output() followed by a call to panic() (buffered output is flushed before that).
 func Panicln(v ...interface{}) {
-	s := fmt.Sprintln(v...)
-	_ = std.Output(FATAL, 4, s)
+	s := sprintln(v...)
+	_ = std.Output(FATAL, std.depth-1, trimNewline(s))
+	_ = std.flush()
 	panic(s)
 }
 
@@ -123,119 +123,13 @@ func TestLevelOutput3(t *testing.T) {
 	}
 }
 
-func TestTraceOutput(t *testing.T) {
-	m1 := 123
-	m2 := "string"
-	res := []string{
-		"logf_test.go:144: [TRACE] 123 string\n",
-		"logf_test.go:146: [TRACE] 123string\n",
-		"logf_test.go:148: [TRACE] 123 string\n",
-	}
-	for i, r := range res {
-		outBuf := new(bytes.Buffer)
-		l := New(
-			WithWriter(outBuf),
-			WithFlags(Llevel|Lshortfile),
-			WithPrefix(""),
-			WithMinLevel(TRACE),
-		)
-		switch i {
-		case 0:
-			l.Tracef("%v %v", m1, m2)
-		case 1:
-			l.Trace(m1, m2)
-		default:
-			l.Traceln(m1, m2)
-		}
-		s := outBuf.String()
-		if s != r {
-			t.Errorf("Logger.Trace(%d, \"%s\")  = \"%v\", want \"%v\".", m1, m2, s, r)
-		}
-	}
-	res2 := []string{
-		"logf_test.go:166: [TRACE] 123 string\n",
-		"logf_test.go:168: [TRACE] 123string\n",
-		"logf_test.go:170: [TRACE] 123 string\n",
-	}
-	for i, r := range res2 {
-		outBuf := new(bytes.Buffer)
-		SetFlags(Llevel | Lshortfile)
-		SetOutput(outBuf)
-		switch i {
-		case 0:
-			Tracef("%v %v", m1, m2)
-		case 1:
-			Trace(m1, m2)
-		default:
-			Traceln(m1, m2)
-		}
-		s := outBuf.String()
-		if s != r {
-			t.Errorf("Logger.Trace(%d, \"%s\")  = \"%v\", want \"%v\".", m1, m2, s, r)
-		}
-	}
-}
-
-func TestDebugOutput(t *testing.T) {
-	m1 := 123
-	m2 := "string"
-	res := []string{
-		"logf_test.go:197: [DEBUG] 123 string\n",
-		"logf_test.go:199: [DEBUG] 123string\n",
-		"logf_test.go:201: [DEBUG] 123 string\n",
-	}
-	for i, r := range res {
-		outBuf := new(bytes.Buffer)
-		l := New(
-			WithWriter(outBuf),
-			WithFlags(Llevel|Lshortfile),
-			WithPrefix(""),
-			WithMinLevel(TRACE),
-		)
-		switch i {
-		case 0:
-			l.Debugf("%v %v", m1, m2)
-		case 1:
-			l.Debug(m1, m2)
-		default:
-			l.Debugln(m1, m2)
-		}
-		s := outBuf.String()
-		if s != r {
-			t.Errorf("Logger.Debug(%d, \"%s\")  = \"%v\", want \"%v\".", m1, m2, s, r)
-		}
-	}
-	res2 := []string{
-		"logf_test.go:219: [DEBUG] 123 string\n",
-		"logf_test.go:221: [DEBUG] 123string\n",
-		"logf_test.go:223: [DEBUG] 123 string\n",
-	}
-	for i, r := range res2 {
-		outBuf := new(bytes.Buffer)
-		SetFlags(Llevel | Lshortfile)
-		SetOutput(outBuf)
-		switch i {
-		case 0:
-			Debugf("%v %v", m1, m2)
-		case 1:
-			Debug(m1, m2)
-		default:
-			Debugln(m1, m2)
-		}
-		s := outBuf.String()
-		if s != r {
-			t.Errorf("Logger.Debug(%d, \"%s\")  = \"%v\", want \"%v\".", m1, m2, s, r)
-		}
-	}
-}
-
 func TestPrintOutput(t *testing.T) {
 	m1 := 123
 	m2 := "string"
 	res := []string{
-		"logf_test.go:250: [INFO] 123 string\n",
-		"logf_test.go:252: [INFO] 123string\n",
-		"logf_test.go:254: [INFO] 123 string\n",
+		"logf_test.go:144: [INFO] 123 string\n",
+		"logf_test.go:146: [INFO] 123string\n",
+		"logf_test.go:148: [INFO] 123 string\n",
 	}
 	for i, r := range res {
 		outBuf := new(bytes.Buffer)
@@ -259,9 +153,9 @@ func TestPrintOutput(t *testing.T) {
 		}
 	}
 	res2 := []string{
-		"logf_test.go:272: [INFO] 123 string\n",
-		"logf_test.go:274: [INFO] 123string\n",
-		"logf_test.go:276: [INFO] 123 string\n",
+		"logf_test.go:166: [INFO] 123 string\n",
+		"logf_test.go:168: [INFO] 123string\n",
+		"logf_test.go:170: [INFO] 123 string\n",
 	}
 	for i, r := range res2 {
 		outBuf := new(bytes.Buffer)
@@ -286,9 +180,9 @@ func TestWarnOutput(t *testing.T) {
 	m1 := 123
 	m2 := "string"
 	res := []string{
-		"logf_test.go:303: [WARN] 123 string\n",
-		"logf_test.go:305: [WARN] 123string\n",
-		"logf_test.go:307: [WARN] 123 string\n",
+		"logf_test.go:197: [WARN] 123 string\n",
+		"logf_test.go:199: [WARN] 123string\n",
+		"logf_test.go:201: [WARN] 123 string\n",
 	}
 	for i, r := range res {
 		outBuf := new(bytes.Buffer)
@@ -312,9 +206,9 @@ func TestWarnOutput(t *testing.T) {
 		}
 	}
 	res2 := []string{
-		"logf_test.go:325: [WARN] 123 string\n",
-		"logf_test.go:327: [WARN] 123string\n",
-		"logf_test.go:329: [WARN] 123 string\n",
+		"logf_test.go:219: [WARN] 123 string\n",
+		"logf_test.go:221: [WARN] 123string\n",
+		"logf_test.go:223: [WARN] 123 string\n",
 	}
 	for i, r := range res2 {
 		outBuf := new(bytes.Buffer)
@@ -339,9 +233,9 @@ func TestErrorOutput(t *testing.T) {
 	m1 := 123
 	m2 := "string"
 	res := []string{
-		"logf_test.go:356: [ERROR] 123 string\n",
-		"logf_test.go:358: [ERROR] 123string\n",
-		"logf_test.go:360: [ERROR] 123 string\n",
+		"logf_test.go:250: [ERROR] 123 string\n",
+		"logf_test.go:252: [ERROR] 123string\n",
+		"logf_test.go:254: [ERROR] 123 string\n",
 	}
 	for i, r := range res {
 		outBuf := new(bytes.Buffer)
@@ -365,9 +259,9 @@ func TestErrorOutput(t *testing.T) {
 		}
 	}
 	res2 := []string{
-		"logf_test.go:378: [ERROR] 123 string\n",
-		"logf_test.go:380: [ERROR] 123string\n",
-		"logf_test.go:382: [ERROR] 123 string\n",
+		"logf_test.go:272: [ERROR] 123 string\n",
+		"logf_test.go:274: [ERROR] 123string\n",
+		"logf_test.go:276: [ERROR] 123 string\n",
 	}
 	for i, r := range res2 {
 		outBuf := new(bytes.Buffer)
@@ -392,9 +286,9 @@ func TestFatalOutput(t *testing.T) {
 	m1 := 123
 	m2 := "string"
 	res := []string{
-		"logf_test.go:409: [FATAL] 123 string\n",
-		"logf_test.go:411: [FATAL] 123string\n",
-		"logf_test.go:413: [FATAL] 123 string\n",
+		"logf_test.go:304: [FATAL] 123 string\n",
+		"logf_test.go:306: [FATAL] 123string\n",
+		"logf_test.go:308: [FATAL] 123 string\n",
 	}
 	for i, r := range res {
 		outBuf := new(bytes.Buffer)
@@ -403,6 +297,7 @@ func TestFatalOutput(t *testing.T) {
 			WithFlags(Llevel|Lshortfile),
 			WithPrefix(""),
 			WithMinLevel(TRACE),
+			WithFatalNoExit(),
 		)
 		switch i {
 		case 0:
@@ -417,10 +312,12 @@ func TestFatalOutput(t *testing.T) {
 			t.Errorf("Logger.Fatal(%d, \"%s\")  = \"%v\", want \"%v\".", m1, m2, s, r)
 		}
 	}
+	defer func(exit func(int)) { std.exit = exit }(std.exit)
+	std.exit = nil
 	res2 := []string{
-		"logf_test.go:431: [FATAL] 123 string\n",
-		"logf_test.go:433: [FATAL] 123string\n",
-		"logf_test.go:435: [FATAL] 123 string\n",
+		"logf_test.go:328: [FATAL] 123 string\n",
+		"logf_test.go:330: [FATAL] 123string\n",
+		"logf_test.go:332: [FATAL] 123 string\n",
 	}
 	for i, r := range res2 {
 		outBuf := new(bytes.Buffer)
@@ -476,9 +373,9 @@ func TestPanicOutput(t *testing.T) {
 	m1 := 123
 	m2 := "string"
 	res := []string{
-		"logf_test.go:451: [FATAL] 123 string\n",
-		"logf_test.go:461: [FATAL] 123string\n",
-		"logf_test.go:471: [FATAL] 123 string\n",
+		"logf_test.go:348: [FATAL] 123 string\n",
+		"logf_test.go:358: [FATAL] 123string\n",
+		"logf_test.go:368: [FATAL] 123 string\n",
 	}
 	for i, r := range res {
 		outBuf := new(bytes.Buffer)
@@ -506,9 +403,9 @@ func TestPanicOutput(t *testing.T) {
 		}
 	}
 	res2 := []string{
-		"[TEST] logf_test.go:522: [FATAL] 123 string\n",
-		"[TEST] logf_test.go:524: [FATAL] 123string\n",
-		"[TEST] logf_test.go:526: [FATAL] 123 string\n",
+		"[TEST] logf_test.go:348: [FATAL] 123 string\n",
+		"[TEST] logf_test.go:358: [FATAL] 123string\n",
+		"[TEST] logf_test.go:368: [FATAL] 123 string\n",
 	}
 	for i, r := range res2 {
 		outBuf := new(bytes.Buffer)
@@ -535,6 +432,41 @@ func TestPanicOutput(t *testing.T) {
 	}
 }
 
+func TestLineTerminator(t *testing.T) {
+	testCase := []struct {
+		m string
+		s string
+	}{
+		{m: "Information", s: "[INFO] Information\n"},
+		{m: "Information\n", s: "[INFO] Information\n"},
+		{m: "Information\n\n", s: "[INFO] Information\n"},
+		{m: "Information\r\n", s: "[INFO] Information\n"},
+		{m: "Info\nrmation\n", s: "[INFO] Info\nrmation\n"},
+		{m: "", s: "[INFO] \n"},
+	}
+	for _, tst := range testCase {
+		for i := 0; i < 3; i++ {
+			outBuf := new(bytes.Buffer)
+			l := New(
+				WithWriter(outBuf),
+				WithFlags(Llevel),
+			)
+			switch i {
+			case 0:
+				l.Printf("%s", tst.m)
+			case 1:
+				l.Print(tst.m)
+			default:
+				l.Println(tst.m)
+			}
+			s := outBuf.String()
+			if s != tst.s {
+				t.Errorf("Logger.Print(%d, %q)  = %q, want %q.", i, tst.m, s, tst.s)
+			}
+		}
+	}
+}
+
 /* Copyright 2018,2019 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
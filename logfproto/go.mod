@@ -0,0 +1,12 @@
+module github.com/spiegel-im-spiegel/logf/logfproto
+
+go 1.17
+
+require (
+	github.com/spiegel-im-spiegel/logf v0.0.0
+	google.golang.org/protobuf v1.28.1
+)
+
+require golang.org/x/sys v0.0.0-20190801041406-cbf593c0f2f3 // indirect
+
+replace github.com/spiegel-im-spiegel/logf => ../
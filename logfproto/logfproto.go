@@ -0,0 +1,74 @@
+//Package logfproto provides structured fields of protocol buffers messages for logf,
+//rendered as JSON by protojson instead of Go's default format. e.g.
+//
+//	logfproto.Proto(logger, "req", req).Print("received")
+//	logger.WithFields(logf.Fields{"req": logfproto.Message(req)}).Print("received")
+//
+//It is separate module, so that logf itself does not depend on protobuf.
+package logfproto
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/spiegel-im-spiegel/logf"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+//Value is value of structured field for protocol buffers message.
+//It implements json.Marshaler (JSON object in JSON format) and fmt.Stringer (compact JSON in text format).
+type Value struct {
+	msg proto.Message
+}
+
+//Message returns Value of msg for structured field.
+func Message(msg proto.Message) Value {
+	return Value{msg: msg}
+}
+
+//Proto returns child logger of l with structured field of msg.
+func Proto(l *logf.Logger, key string, msg proto.Message) *logf.Logger {
+	return l.WithFields(logf.Fields{key: Message(msg)})
+}
+
+//MarshalJSON is method of json.Marshaler interface.
+func (v Value) MarshalJSON() ([]byte, error) {
+	if v.msg == nil {
+		return []byte("null"), nil
+	}
+	b, err := protojson.Marshal(v.msg)
+	if err != nil {
+		return nil, err
+	}
+	//protojson output is unstable in white spaces
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, b); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+//String is method of fmt.Stringer interface.
+func (v Value) String() string {
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return "%!PROTO(" + err.Error() + ")"
+	}
+	return string(b)
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
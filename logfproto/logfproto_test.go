@@ -0,0 +1,59 @@
+package logfproto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/logf"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func sampleMessage() proto.Message {
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("greeter.proto"),
+		Package:    proto.String("greeter"),
+		Dependency: []string{"common.proto"},
+	}
+}
+
+func TestProto(t *testing.T) {
+	testCase := []struct {
+		f logf.Format
+		s string
+	}{
+		{f: logf.JSON, s: `"msg":"received","req":{"name":"greeter.proto","package":"greeter","dependency":["common.proto"]}}` + "\n"},
+		{f: logf.LOGFMT, s: `msg=received req="{\"name\":\"greeter.proto\",\"package\":\"greeter\",\"dependency\":[\"common.proto\"]}"` + "\n"},
+		{f: logf.TEXT, s: `received req="{\"name\":\"greeter.proto\",\"package\":\"greeter\",\"dependency\":[\"common.proto\"]}"` + "\n"},
+	}
+	for _, tst := range testCase {
+		buf := new(bytes.Buffer)
+		l := logf.New(logf.WithWriter(buf), logf.WithFlags(logf.Llevel), logf.WithFormat(tst.f))
+		Proto(l, "req", sampleMessage()).Print("received")
+		if s := buf.String(); !strings.HasSuffix(s, tst.s) {
+			t.Errorf("Proto() in %v = \"%v\", want suffix \"%v\".", tst.f, s, tst.s)
+		}
+	}
+}
+
+func TestMessageNil(t *testing.T) {
+	if s := Message(nil).String(); s != "null" {
+		t.Errorf("Message(nil).String() = \"%v\", want \"null\".", s)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
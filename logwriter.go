@@ -0,0 +1,151 @@
+package logf
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"strings"
+	"sync"
+)
+
+//logWriter is io.Writer which writes into Logger at a fixed level.
+type logWriter struct {
+	l  *Logger
+	lv Level
+}
+
+//Write is method of io.Writer interface.
+//Each call is written as one logging event.
+func (w *logWriter) Write(p []byte) (int, error) {
+	if err := w.l.Output(w.lv, 3, trimNewline(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+//AsLogWriter returns io.Writer which writes into the logger at level lv.
+func (l *Logger) AsLogWriter(lv Level) io.Writer {
+	return &logWriter{l: l, lv: lv}
+}
+
+//classifyingWriter is io.WriteCloser which writes into Logger at level classified by leading token of each line.
+type classifyingWriter struct {
+	l   *Logger
+	mu  sync.Mutex
+	buf []byte // partial trailing line (written when line terminator arrives or by Close)
+}
+
+//levelAliases are leading tokens of lines mapped to Level (besides names of Level).
+var levelAliases = map[string]Level{
+	"WARNING": WARN,
+	"ERR":     ERROR,
+}
+
+//Write is method of io.Writer interface.
+//Each complete line is written as one logging event; a partial trailing line is kept until its line terminator arrives.
+func (w *classifyingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	rest := w.buf
+	for {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			break
+		}
+		lv, msg := classifyLine(strings.TrimSuffix(string(rest[:i]), "\r"))
+		rest = rest[i+1:]
+		if err := w.l.Output(lv, 3, msg); err != nil {
+			w.buf = append(w.buf[:0], rest...)
+			return 0, err
+		}
+	}
+	w.buf = append(w.buf[:0], rest...)
+	return len(p), nil
+}
+
+//Close is method of io.Closer interface.
+//It writes the partial trailing line (if any). The logger is not closed.
+func (w *classifyingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) == 0 {
+		return nil
+	}
+	lv, msg := classifyLine(strings.TrimSuffix(string(w.buf), "\r"))
+	w.buf = w.buf[:0]
+	return w.l.Output(lv, 3, msg)
+}
+
+//classifyLine returns level of leading token of line ("ERROR:", "[WARN]" and so on; INFO by default)
+//and line without the token. The token is an upper-case level name followed by ':' or enclosed in brackets.
+func classifyLine(line string) (Level, string) {
+	var name, rest string
+	if strings.HasPrefix(line, "[") {
+		i := strings.IndexByte(line, ']')
+		if i < 0 {
+			return INFO, line
+		}
+		name, rest = line[1:i], line[i+1:]
+	} else {
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			return INFO, line
+		}
+		name, rest = line[:i], line[i+1:]
+	}
+	if name != strings.ToUpper(name) {
+		return INFO, line
+	}
+	lv, ok := levelAliases[name]
+	if !ok {
+		lv, ok = parseLevel(name)
+	}
+	if !ok {
+		return INFO, line
+	}
+	return lv, strings.TrimPrefix(rest, " ")
+}
+
+//ClassifyingWriter returns io.WriteCloser which writes each line into the logger at level classified
+//by its leading token (e.g. "ERROR: ...", "WARNING: ..." or "[DEBUG] ..."; INFO by default).
+//The token is stripped from the message. It is useful for capturing output of subprocess:
+//lines split across writes are joined, and Close writes the last line without line terminator.
+func (l *Logger) ClassifyingWriter() io.WriteCloser {
+	return &classifyingWriter{l: l}
+}
+
+//Adopt redirects output of standard *log.Logger into the logger at level lv.
+//Flags and prefix of std are cleared, the logger puts its own.
+func (l *Logger) Adopt(std *log.Logger, lv Level) {
+	if std == nil {
+		return
+	}
+	std.SetFlags(0)
+	std.SetPrefix("")
+	std.SetOutput(l.AsLogWriter(lv))
+}
+
+//AsLogWriter returns io.Writer which writes into the logger at level lv.
+func AsLogWriter(lv Level) io.Writer { return std.AsLogWriter(lv) }
+
+//ClassifyingWriter returns io.WriteCloser which writes each line into the logger at level classified by its leading token.
+func ClassifyingWriter() io.WriteCloser { return std.ClassifyingWriter() }
+
+//Adopt redirects output of standard *log.Logger into the logger at level lv.
+func Adopt(l *log.Logger, lv Level) { std.Adopt(l, lv) }
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,145 @@
+package logf
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestAsLogWriter(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel),
+		WithMinLevel(INFO),
+	)
+	w := l.AsLogWriter(WARN)
+	if _, err := w.Write([]byte("Warning\n")); err != nil {
+		t.Errorf("Write() = %v, want nil.", err)
+	}
+	if _, err := l.AsLogWriter(DEBUG).Write([]byte("Debugging\n")); err != nil {
+		t.Errorf("Write() = %v, want nil.", err)
+	}
+	res := "[WARN] Warning\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Logger output = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestAdopt(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel),
+		WithPrefix("[TEST] "),
+	)
+	stdLogger := log.New(new(bytes.Buffer), "[STD] ", log.LstdFlags)
+	l.Adopt(stdLogger, ERROR)
+	stdLogger.Println("Erroring")
+	stdLogger.Printf("%s No. %d", "Erroring", 2)
+	res := "[TEST] [ERROR] Erroring\n[TEST] [ERROR] Erroring No. 2\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Logger output = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestClassifyingWriter(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel))
+	w := l.ClassifyingWriter()
+	if _, err := w.Write([]byte("ERROR: disk full\nWARN: low memory\r\n")); err != nil {
+		t.Errorf("Write() = \"%v\", want nil.", err)
+	}
+	for _, line := range []string{"WARNING: deprecated\n", "[DEBUG] cache miss\n", "plain message\n", "Errors: 3\n", "FATAL:\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Errorf("Write() = \"%v\", want nil.", err)
+		}
+	}
+	res := "[ERROR] disk full\n" +
+		"[WARN] low memory\n" +
+		"[WARN] deprecated\n" +
+		"[DEBUG] cache miss\n" +
+		"[INFO] plain message\n" +
+		"[INFO] Errors: 3\n" +
+		"[FATAL] \n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("ClassifyingWriter() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestAsLogWriterCaller(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel|Lshortfile))
+	_, _ = l.AsLogWriter(WARN).Write([]byte("Warning\n"))
+	res := "logwriter_test.go:73: [WARN] Warning\n"
+	if s := buf.String(); s != res {
+		t.Errorf("Logger output = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestClassifyingWriterCaller(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel|Lshortfile))
+	_, _ = l.ClassifyingWriter().Write([]byte("ERROR: failed\nWARN: slow\n"))
+	res := "logwriter_test.go:83: [ERROR] failed\nlogwriter_test.go:83: [WARN] slow\n"
+	if s := buf.String(); s != res {
+		t.Errorf("Logger output = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestClassifyingWriterProse(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel))
+	w := l.ClassifyingWriter()
+	for _, line := range []string{"Error rate is 0.1%\n", "Info panel opened\n", "WARN low memory\n", "error: lower case\n", "[Debug] mixed case\n", "NOTE: not a level\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Errorf("Write() = \"%v\", want nil.", err)
+		}
+	}
+	res := "[INFO] Error rate is 0.1%\n" +
+		"[INFO] Info panel opened\n" +
+		"[INFO] WARN low memory\n" +
+		"[INFO] error: lower case\n" +
+		"[INFO] [Debug] mixed case\n" +
+		"[INFO] NOTE: not a level\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("ClassifyingWriter() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestClassifyingWriterPartial(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel))
+	w := l.ClassifyingWriter()
+	for _, chunk := range []string{"ERROR: disk fa", "iled\nWARN", "ING: retry", "ing\nlast line"} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Errorf("Write() = \"%v\", want nil.", err)
+		}
+	}
+	res := "[ERROR] disk failed\n[WARN] retrying\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("ClassifyingWriter() = \"%v\", want \"%v\".", s, res)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() = \"%v\", want nil.", err)
+	}
+	res += "[INFO] last line\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("ClassifyingWriter() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
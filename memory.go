@@ -0,0 +1,92 @@
+package logf
+
+import "strings"
+
+//ringBuffer keeps the most recent log lines in memory.
+type ringBuffer struct {
+	lines []string
+	next  int
+	full  bool
+}
+
+func newRingBuffer(n int) *ringBuffer {
+	return &ringBuffer{lines: make([]string, n)}
+}
+
+//add stores line, overwriting the oldest one at capacity.
+func (r *ringBuffer) add(line string) {
+	r.lines[r.next] = line
+	r.next++
+	if r.next >= len(r.lines) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+//list returns stored lines from oldest to newest.
+func (r *ringBuffer) list() []string {
+	if !r.full {
+		return append([]string{}, r.lines[:r.next]...)
+	}
+	return append(append([]string{}, r.lines[r.next:]...), r.lines[:r.next]...)
+}
+
+//WithMemoryBuffer returns function for retaining the last n log lines in memory.
+//Lines are retained regardless of minimum level. If n <= 0, the buffer is disabled.
+func WithMemoryBuffer(n int) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if n > 0 {
+			l.mem = newRingBuffer(n)
+		} else {
+			l.mem = nil
+		}
+	}
+}
+
+//remember stores a logging event in memory buffer.
+//Fields are rendered only if memory buffer is enabled.
+func (l *Logger) remember(lv Level, s string, fields []Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.mem != nil {
+		l.mem.add(l.header(lv, false) + strings.TrimSuffix(s, "\n") + l.renderFields(fields))
+	}
+}
+
+//rememberFiltered stores a logging event filtered by minimum level in memory buffer.
+//Lazy fields are not evaluated, others are transformed and capped as written events.
+func (l *Logger) rememberFiltered(lv Level, s string, fields []Field) {
+	l.mu.Lock()
+	enabled := l.mem != nil
+	l.mu.Unlock()
+	if enabled {
+		l.remember(lv, s, l.capFields(l.transformFields(groupFields(l.expandErrors(eagerFields(fields))))))
+	}
+}
+
+//Dump returns lines retained by memory buffer (oldest first).
+func (l *Logger) Dump() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.mem == nil {
+		return nil
+	}
+	return l.mem.list()
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
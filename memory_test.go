@@ -0,0 +1,98 @@
+package logf
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestMemoryBuffer(t *testing.T) {
+	testCase := []struct {
+		n    int
+		cnt  int
+		dump []string
+	}{
+		{n: 3, cnt: 0, dump: []string{}},
+		{n: 3, cnt: 2, dump: []string{"[DEBUG] No. 1", "[DEBUG] No. 2"}},
+		{n: 3, cnt: 3, dump: []string{"[DEBUG] No. 1", "[DEBUG] No. 2", "[DEBUG] No. 3"}},
+		{n: 3, cnt: 5, dump: []string{"[DEBUG] No. 3", "[DEBUG] No. 4", "[DEBUG] No. 5"}},
+		{n: 3, cnt: 7, dump: []string{"[DEBUG] No. 5", "[DEBUG] No. 6", "[DEBUG] No. 7"}},
+		{n: 0, cnt: 2, dump: nil},
+	}
+	for _, tst := range testCase {
+		outBuf := new(bytes.Buffer)
+		l := New(
+			WithWriter(outBuf),
+			WithFlags(Llevel),
+			WithMinLevel(INFO),
+			WithMemoryBuffer(tst.n),
+		)
+		for i := 0; i < tst.cnt; i++ {
+			_ = l.Output(DEBUG, 2, fmt.Sprintf("No. %d", i+1))
+		}
+		if outBuf.Len() != 0 {
+			t.Errorf("Logger.Debugf() = \"%v\", want \"\".", outBuf.String())
+		}
+		dump := l.Dump()
+		if !reflect.DeepEqual(dump, tst.dump) {
+			t.Errorf("Logger.Dump() = %v, want %v.", dump, tst.dump)
+		}
+	}
+}
+
+func TestMemoryBufferMixed(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel),
+		WithMinLevel(WARN),
+		WithMemoryBuffer(2),
+	)
+	l.Println("Information")
+	l.Warn("Warning")
+	l.Error(fmt.Errorf("Erroring"))
+	res := []string{"[WARN] Warning", "[ERROR] Erroring"}
+	if dump := l.Dump(); !reflect.DeepEqual(dump, res) {
+		t.Errorf("Logger.Dump() = %v, want %v.", dump, res)
+	}
+	res2 := "[WARN] Warning\n[ERROR] Erroring\n"
+	if s := outBuf.String(); s != res2 {
+		t.Errorf("Logger output = \"%v\", want \"%v\".", s, res2)
+	}
+}
+
+func TestMemoryBufferTransformer(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel),
+		WithMinLevel(INFO),
+		WithMemoryBuffer(2),
+		WithFieldTransformer(func(key string, value interface{}) (string, interface{}, bool) {
+			return key, value, key != "password"
+		}),
+	)
+	c := l.WithFields(Fields{"password": "hunter2", "user": "alice"})
+	_ = c.Output(DEBUG, 2, "login")
+	c.Print("logged in")
+	res := []string{"[DEBUG] login user=alice", "[INFO] logged in user=alice"}
+	if dump := l.Dump(); !reflect.DeepEqual(dump, res) {
+		t.Errorf("Logger.Dump() = %v, want %v.", dump, res)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
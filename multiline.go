@@ -0,0 +1,79 @@
+package logf
+
+import "strings"
+
+//WithMultilinePrefix returns function for putting prefix (time, level, ...) on each line of multi-line message.
+//It keeps line-based parsers working with embedded newlines (e.g. stack traces).
+//It has effect on text format only.
+func WithMultilinePrefix(multiline bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.multiline = multiline
+	}
+}
+
+//WithRawMessage returns function for writing message of print functions verbatim:
+//trailing and consecutive line terminators are kept (a line terminator is added only if missing).
+//With WithMultilinePrefix option, each line (including blank ones) is prefixed.
+func WithRawMessage(raw bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.raw = raw
+	}
+}
+
+//WithSingleLine returns function for forcing each message onto one physical line.
+//Line terminators in message are replaced with escapes (e.g. `first\nsecond`) and trailing ones are removed.
+//It is simpler alternative to WithMultilinePrefix option for line-based log systems.
+func WithSingleLine(single bool) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.singleLine = single
+	}
+}
+
+//singleLineReplacer escapes line terminators.
+var singleLineReplacer = strings.NewReplacer("\r\n", `\r\n`, "\n", `\n`, "\r", `\r`)
+
+//escapeLines returns message with escaped line terminators if WithSingleLine option is set.
+func (l *Logger) escapeLines(s string) string {
+	l.mu.Lock()
+	single := l.singleLine
+	l.mu.Unlock()
+	if !single || !strings.ContainsAny(s, "\r\n") {
+		return s
+	}
+	return singleLineReplacer.Replace(trimNewline(s))
+}
+
+//splitLines splits message into lines if WithMultilinePrefix option is set.
+func (l *Logger) splitLines(s string) []string {
+	l.mu.Lock()
+	multiline, raw := l.multiline, l.raw
+	l.mu.Unlock()
+	if !multiline || !strings.ContainsAny(s, "\r\n") {
+		return []string{s}
+	}
+	if raw {
+		return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,106 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMultilinePrefix(t *testing.T) {
+	testCase := []struct {
+		multiline bool
+		s         string
+	}{
+		{multiline: false, s: "[ERROR] first\nsecond\n"},
+		{multiline: true, s: "[ERROR] first\n[ERROR] second\n"},
+	}
+	for _, tst := range testCase {
+		buf := &bytes.Buffer{}
+		l := New(WithWriter(buf), WithFlags(Llevel), WithMultilinePrefix(tst.multiline))
+		l.Error("first\nsecond")
+		if str := buf.String(); str != tst.s {
+			t.Errorf("WithMultilinePrefix(%v)  = \"%v\", want \"%v\".", tst.multiline, str, tst.s)
+		}
+	}
+}
+
+func TestMultilinePrefixLevelWriter(t *testing.T) {
+	w := &testLevelWriter{}
+	l := New(WithWriter(w), WithFlags(Llevel), WithMultilinePrefix(true))
+	l.Error("first\nsecond")
+	res := "4|[ERROR] first\n4|[ERROR] second\n"
+	if str := w.String(); str != res {
+		t.Errorf("WithMultilinePrefix(true)  = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+func TestMultilinePrefixCaller(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Lshortfile|Llevel), WithMultilinePrefix(true))
+	l.Errorf("first\r\nsecond")
+	res := "multiline_test.go:39: [ERROR] first\nmultiline_test.go:39: [ERROR] second\n"
+	if str := buf.String(); str != res {
+		t.Errorf("WithMultilinePrefix(true)  = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+func TestWithRawMessage(t *testing.T) {
+	testCase := []struct {
+		multiline bool
+		raw       bool
+		s         string
+	}{
+		{multiline: false, raw: false, s: "[INFO] first\n\n\nsecond\n"},
+		{multiline: false, raw: true, s: "[INFO] first\n\n\nsecond\n\n"},
+		{multiline: true, raw: true, s: "[INFO] first\n[INFO] \n[INFO] \n[INFO] second\n[INFO] \n"},
+	}
+	for _, tst := range testCase {
+		buf := &bytes.Buffer{}
+		l := New(WithWriter(buf), WithFlags(Llevel), WithMultilinePrefix(tst.multiline), WithRawMessage(tst.raw))
+		l.Print("first\n\n\nsecond\n\n")
+		if str := buf.String(); str != tst.s {
+			t.Errorf("WithRawMessage(%v) = %q, want %q.", tst.raw, str, tst.s)
+		}
+	}
+}
+
+func TestWithSingleLine(t *testing.T) {
+	testCase := []struct {
+		f Format
+		m string
+		s string
+	}{
+		{f: TEXT, m: "first\nsecond\r\nthird\n", s: "[INFO] first\\nsecond\\r\\nthird\n"},
+		{f: TEXT, m: "single", s: "[INFO] single\n"},
+		{f: LOGFMT, m: "first\nsecond", s: "level=INFO msg=first\\nsecond\n"},
+	}
+	for _, tst := range testCase {
+		buf := &bytes.Buffer{}
+		l := New(WithWriter(buf), WithFlags(Llevel), WithFormat(tst.f), WithSingleLine(true), WithMultilinePrefix(true), WithRawMessage(true))
+		l.Print(tst.m)
+		str := buf.String()
+		if tst.f == LOGFMT {
+			str = str[bytes.Index(buf.Bytes(), []byte("level=")):]
+		}
+		if str != tst.s {
+			t.Errorf("WithSingleLine(true) = %q, want %q.", str, tst.s)
+		}
+		if n := bytes.Count(buf.Bytes(), []byte("\n")); n != 1 {
+			t.Errorf("WithSingleLine(true) writes %d lines, want 1.", n)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
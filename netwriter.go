@@ -0,0 +1,221 @@
+package logf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+//ErrNetWriterFailed is reported when NetWriter gives up reconnecting.
+var ErrNetWriterFailed = errors.New("network writer gave up reconnecting")
+
+//NetWriter is io.Writer which sends log entries to a remote collector by TCP or UDP.
+//Entries written while disconnected are buffered and sent after reconnection.
+//It is safe for concurrent use.
+type NetWriter struct {
+	mu         sync.Mutex
+	network    string
+	addr       string
+	conn       net.Conn
+	pending    [][]byte      // entries buffered while disconnected
+	maxPending int           // max number of buffered entries
+	minBackoff time.Duration // initial interval of reconnection
+	maxBackoff time.Duration // max interval of reconnection
+	backoff    time.Duration // current interval of reconnection
+	nextDial   time.Time     // time of next reconnection
+	retries    int           // count of consecutive dial failures
+	maxRetries int           // max count of dial failures (0 is unlimited)
+	failed     bool          // gave up reconnecting
+	timeout    time.Duration // write deadline of each entry (0 is unlimited)
+	errHandler func(error)
+	fallback   io.Writer
+	dial       func(network, addr string) (net.Conn, error)
+	now        func() time.Time
+}
+
+//NetOptFunc is self-referential function for functional options pattern of NetWriter
+type NetOptFunc func(*NetWriter)
+
+//NewNetWriter returns NetWriter for network ("tcp", "udp", ...) and address.
+//Connection is established at the first write.
+func NewNetWriter(network, addr string, opts ...NetOptFunc) *NetWriter {
+	w := &NetWriter{
+		network:    network,
+		addr:       addr,
+		maxPending: 1000,
+		minBackoff: 100 * time.Millisecond,
+		maxBackoff: 30 * time.Second,
+		timeout:    5 * time.Second,
+		dial:       net.Dial,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.backoff = w.minBackoff
+	return w
+}
+
+//WithNetBackoff returns function for setting initial and max intervals of reconnection.
+//The interval is doubled on each failure.
+func WithNetBackoff(min, max time.Duration) NetOptFunc {
+	return func(w *NetWriter) {
+		if min > max {
+			min, max = max, min
+		}
+		w.minBackoff = min
+		w.maxBackoff = max
+	}
+}
+
+//WithNetMaxRetries returns function for setting max count of consecutive reconnection failures.
+//After that NetWriter gives up (permanent failure). If n is 0, it retries forever.
+func WithNetMaxRetries(n int) NetOptFunc {
+	return func(w *NetWriter) {
+		w.maxRetries = n
+	}
+}
+
+//WithNetWriteTimeout returns function for setting write deadline of each entry (default 5 seconds).
+//A stalled collector is treated as write error (the connection is closed and reconnected later).
+//If d is 0 or less, writes have no deadline.
+func WithNetWriteTimeout(d time.Duration) NetOptFunc {
+	return func(w *NetWriter) {
+		w.timeout = d
+	}
+}
+
+//WithNetBufferSize returns function for setting max number of entries buffered while disconnected.
+//The oldest entries are dropped on overflow.
+func WithNetBufferSize(n int) NetOptFunc {
+	return func(w *NetWriter) {
+		w.maxPending = n
+	}
+}
+
+//WithNetErrorHandler returns function for setting handler of connection errors.
+func WithNetErrorHandler(h func(error)) NetOptFunc {
+	return func(w *NetWriter) {
+		w.errHandler = h
+	}
+}
+
+//WithNetFallback returns function for setting writer (e.g. os.Stderr) used after permanent failure.
+func WithNetFallback(fallback io.Writer) NetOptFunc {
+	return func(w *NetWriter) {
+		w.fallback = fallback
+	}
+}
+
+//Write is method of io.Writer interface.
+func (w *NetWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failed {
+		if w.fallback != nil {
+			return w.fallback.Write(p)
+		}
+		return 0, ErrNetWriterFailed
+	}
+	w.push(p)
+	if w.conn == nil && !w.connect() {
+		if w.failed && w.fallback != nil {
+			return w.fallback.Write(p)
+		}
+		return len(p), nil
+	}
+	w.flush()
+	return len(p), nil
+}
+
+//Close sends buffered entries (if connected) and closes connection.
+func (w *NetWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	w.flush()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+//push buffers a copy of entry.
+func (w *NetWriter) push(p []byte) {
+	if w.maxPending > 0 && len(w.pending) >= w.maxPending {
+		w.pending = w.pending[1:]
+	}
+	w.pending = append(w.pending, append([]byte{}, p...))
+}
+
+//flush sends buffered entries. On error (including timeout), the connection is closed and rest entries are kept.
+func (w *NetWriter) flush() {
+	for len(w.pending) > 0 {
+		if w.timeout > 0 {
+			_ = w.conn.SetWriteDeadline(time.Now().Add(w.timeout))
+		}
+		if _, err := w.conn.Write(w.pending[0]); err != nil {
+			_ = w.conn.Close()
+			w.conn = nil
+			w.handleError(err)
+			return
+		}
+		w.pending = w.pending[1:]
+	}
+}
+
+//connect dials the address if backoff interval elapsed.
+func (w *NetWriter) connect() bool {
+	now := w.now()
+	if now.Before(w.nextDial) {
+		return false
+	}
+	conn, err := w.dial(w.network, w.addr)
+	if err != nil {
+		w.retries++
+		w.nextDial = now.Add(w.backoff)
+		if w.backoff *= 2; w.backoff > w.maxBackoff {
+			w.backoff = w.maxBackoff
+		}
+		w.handleError(err)
+		if w.maxRetries > 0 && w.retries >= w.maxRetries {
+			w.failed = true
+			w.pending = nil
+			w.handleError(fmt.Errorf("%w: %s %s", ErrNetWriterFailed, w.network, w.addr))
+		}
+		return false
+	}
+	w.conn = conn
+	w.retries = 0
+	w.backoff = w.minBackoff
+	w.nextDial = time.Time{}
+	return true
+}
+
+func (w *NetWriter) handleError(err error) {
+	if w.errHandler != nil {
+		w.errHandler(err)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,186 @@
+package logf
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNetWriterReconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	defer ln.Close()
+
+	lines := make(chan string, 100)
+	accepted := make(chan net.Conn, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+			go func(c net.Conn) {
+				sc := bufio.NewScanner(c)
+				for sc.Scan() {
+					lines <- sc.Text()
+				}
+			}(conn)
+		}
+	}()
+
+	w := NewNetWriter("tcp", ln.Addr().String(), WithNetBackoff(time.Millisecond, 10*time.Millisecond))
+	defer w.Close()
+	l := New(WithWriter(w), WithFlags(0))
+	l.Print("first")
+	if got := waitLine(t, lines); got != "first" {
+		t.Errorf("NetWriter  = \"%v\", want \"%v\".", got, "first")
+	}
+
+	//drop connection
+	(<-accepted).Close()
+	reconnected := false
+	for i := 0; i < 200 && !reconnected; i++ {
+		l.Print("second")
+		select {
+		case <-accepted:
+			reconnected = true
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	if !reconnected {
+		t.Fatal("NetWriter did not reconnect")
+	}
+	l.Print("third")
+	for {
+		got := waitLine(t, lines)
+		if got == "third" {
+			break
+		}
+		if got != "second" {
+			t.Errorf("NetWriter  = \"%v\", want \"%v\".", got, "second")
+		}
+	}
+}
+
+func TestNetWriterPermanentFailure(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	var errs []error
+	fallback := &bytes.Buffer{}
+	w := NewNetWriter("tcp", addr,
+		WithNetBackoff(0, 0),
+		WithNetMaxRetries(2),
+		WithNetErrorHandler(func(err error) { errs = append(errs, err) }),
+		WithNetFallback(fallback),
+	)
+	l := New(WithWriter(w), WithFlags(0))
+	l.Print("first")
+	l.Print("second")
+	l.Print("third")
+	if len(errs) == 0 || !errors.Is(errs[len(errs)-1], ErrNetWriterFailed) {
+		t.Errorf("NetWriter errors = \"%v\", want \"%v\".", errs, ErrNetWriterFailed)
+	}
+	res := "second\nthird\n"
+	if str := fallback.String(); str != res {
+		t.Errorf("NetWriter fallback = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+func TestNetWriterWriteTimeout(t *testing.T) {
+	var errs []error
+	w := NewNetWriter("tcp", "stalled", WithNetWriteTimeout(20*time.Millisecond), WithNetErrorHandler(func(err error) { errs = append(errs, err) }))
+	var peer net.Conn
+	w.dial = func(network, addr string) (net.Conn, error) {
+		conn, p := net.Pipe() //nobody reads from p
+		peer = p
+		return conn, nil
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = w.Write([]byte("stalled\n"))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("NetWriter.Write() is blocked by stalled connection.")
+	}
+	defer peer.Close()
+	var ne net.Error
+	if len(errs) != 1 || !errors.As(errs[0], &ne) || !ne.Timeout() {
+		t.Errorf("NetWriter errors = \"%v\", want timeout.", errs)
+	}
+	if w.conn != nil || len(w.pending) != 1 {
+		t.Errorf("NetWriter conn = %v, pending = %d, want nil, 1.", w.conn, len(w.pending))
+	}
+}
+
+func TestNetWriterUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	defer pc.Close()
+
+	w := NewNetWriter("udp", pc.LocalAddr().String())
+	defer w.Close()
+	l := New(WithWriter(w), WithFlags(0))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Print("hello")
+		}()
+	}
+	wg.Wait()
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 1024)
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom() = \"%v\", want nil.", err)
+	}
+	res := "hello"
+	if str := strings.TrimSpace(string(buf[:n])); str != res {
+		t.Errorf("NetWriter  = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+func waitLine(t *testing.T, lines <-chan string) string {
+	t.Helper()
+	select {
+	case s := <-lines:
+		return s
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for log line")
+	}
+	return ""
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,48 @@
+package logf
+
+//WarnOnce prints msg at WARN level only at the first call with the same msg
+//(e.g. deprecation warnings in hot loops). Subsequent calls are suppressed
+//for the lifetime of the logger.
+func (l *Logger) WarnOnce(msg string) {
+	if l.seen(msg) {
+		return
+	}
+	_ = l.Output(WARN, l.depth-1, msg)
+}
+
+//WarnOnce calls std.WarnOnce() to print to the logger.
+func WarnOnce(msg string) {
+	if std.seen(msg) {
+		return
+	}
+	_ = std.Output(WARN, std.depth-1, msg)
+}
+
+//seen returns true if msg was printed by WarnOnce method, and marks it as printed.
+func (l *Logger) seen(msg string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.once[msg]; ok {
+		return true
+	}
+	if l.once == nil {
+		l.once = map[string]struct{}{}
+	}
+	l.once[msg] = struct{}{}
+	return false
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
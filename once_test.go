@@ -0,0 +1,36 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWarnOnce(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel|Lshortfile))
+	for i := 0; i < 3; i++ {
+		l.WarnOnce("deprecation: use X instead")
+	}
+	l.WarnOnce("deprecation: use Y instead")
+	l.WithFields(Fields{"id": 1}).WarnOnce("deprecation: use Y instead")
+	res := "once_test.go:12: [WARN] deprecation: use X instead\n" +
+		"once_test.go:14: [WARN] deprecation: use Y instead\n"
+	if s := buf.String(); s != res {
+		t.Errorf("WarnOnce() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,305 @@
+package logf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestHostnameAndPID(t *testing.T) {
+	host, err := os.Hostname()
+	if err != nil || len(host) == 0 {
+		host = "unknown"
+	}
+	pid := os.Getpid()
+	testCase := []struct {
+		opts []OptFunc
+		s    string
+	}{
+		{opts: []OptFunc{WithHostname(), WithPID()}, s: fmt.Sprintf("[%s:%d] [INFO] Information\n", host, pid)},
+		{opts: []OptFunc{WithHostname()}, s: fmt.Sprintf("[%s] [INFO] Information\n", host)},
+		{opts: []OptFunc{WithPID()}, s: fmt.Sprintf("[%d] [INFO] Information\n", pid)},
+		{opts: []OptFunc{}, s: "[INFO] Information\n"},
+	}
+	for _, tst := range testCase {
+		outBuf := new(bytes.Buffer)
+		l := New(append([]OptFunc{WithWriter(outBuf), WithFlags(Llevel)}, tst.opts...)...)
+		l.Print("Information")
+		s := outBuf.String()
+		if s != tst.s {
+			t.Errorf("Logger.Print()  = \"%v\", want \"%v\".", s, tst.s)
+		}
+	}
+}
+
+func TestMinLevelVar(t *testing.T) {
+	v := &LevelVar{}
+	v.Set(WARN)
+	outBuf1 := new(bytes.Buffer)
+	l1 := New(WithWriter(outBuf1), WithFlags(Llevel), WithMinLevelVar(v))
+	outBuf2 := new(bytes.Buffer)
+	l2 := New(WithWriter(outBuf2), WithFlags(Llevel), WithMinLevelVar(v))
+
+	l1.Print("Information 1")
+	l2.Print("Information 1")
+	v.Set(INFO)
+	if l1.MinLevel() != INFO {
+		t.Errorf("Logger.MinLevel()  = %v, want %v.", l1.MinLevel(), INFO)
+	}
+	l1.Print("Information 2")
+	l2.Print("Information 2")
+	l2.SetMinLevel(ERROR) //detach variable
+	v.Set(TRACE)
+	l1.Print("Information 3")
+	l2.Print("Information 3")
+
+	res1 := "[INFO] Information 2\n[INFO] Information 3\n"
+	if s := outBuf1.String(); s != res1 {
+		t.Errorf("Logger.Print()  = \"%v\", want \"%v\".", s, res1)
+	}
+	res2 := "[INFO] Information 2\n"
+	if s := outBuf2.String(); s != res2 {
+		t.Errorf("Logger.Print()  = \"%v\", want \"%v\".", s, res2)
+	}
+	if l2.MinLevel() != ERROR {
+		t.Errorf("Logger.MinLevel()  = %v, want %v.", l2.MinLevel(), ERROR)
+	}
+}
+
+func TestWithUTC(t *testing.T) {
+	testCase := []struct {
+		opts []OptFunc
+		flag int
+		err  error
+	}{
+		{opts: []OptFunc{WithUTC(true)}, flag: LstdFlags | LUTC, err: nil},
+		{opts: []OptFunc{WithFlags(Ltime | Lshortfile), WithUTC(true)}, flag: Ltime | Lshortfile | LUTC, err: nil},
+		{opts: []OptFunc{WithFlags(LstdFlags | LUTC), WithUTC(false)}, flag: LstdFlags, err: nil},
+		{opts: []OptFunc{WithFlags(Llevel), WithUTC(true)}, flag: Llevel | LUTC, err: ErrUTCWithoutTime},
+		{opts: []OptFunc{WithUTC(true), WithFlags(Llevel | LUTC)}, flag: Llevel | LUTC, err: ErrUTCWithoutTime},
+		{opts: []OptFunc{WithFlags(Llevel), WithUTC(false)}, flag: Llevel, err: nil},
+	}
+	for _, tst := range testCase {
+		var errs []error
+		l := New(append([]OptFunc{WithErrorHandler(func(err error) { errs = append(errs, err) })}, tst.opts...)...)
+		if l.Flags() != tst.flag {
+			t.Errorf("Logger.Flags()  = %#x, want %#x.", l.Flags(), tst.flag)
+		}
+		if tst.err == nil && len(errs) > 0 {
+			t.Errorf("error handler called with %v, want no call.", errs)
+		}
+		if tst.err != nil && (len(errs) != 1 || errs[0] != tst.err) {
+			t.Errorf("error handler called with %v, want \"%v\".", errs, tst.err)
+		}
+	}
+}
+
+type wrappedLogger struct {
+	l *Logger
+}
+
+func (w *wrappedLogger) Info(msg string)  { w.l.Print(msg) }
+func (w *wrappedLogger) Error(msg string) { w.l.ErrorWithErr(msg, nil) }
+
+func TestBaseCallDepth(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	w := &wrappedLogger{l: New(
+		WithWriter(outBuf),
+		WithFlags(Llevel|Lshortfile),
+		WithBaseCallDepth(5),
+	)}
+	w.Info("Information")
+	w.Error("Erroring")
+	res := "options_test.go:112: [INFO] Information\noptions_test.go:113: [ERROR] Erroring\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Logger output  = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestWithWriterFunc(t *testing.T) {
+	var writes []string
+	l := New(WithWriterFunc(func(p []byte) (int, error) {
+		writes = append(writes, string(p))
+		return len(p), nil
+	}), WithFlags(Llevel))
+	l.Print("hello")
+	l.Error("world")
+	res := []string{"[INFO] hello\n", "[ERROR] world\n"}
+	if len(writes) != len(res) {
+		t.Fatalf("WithWriterFunc() writes = %q, want %q.", writes, res)
+	}
+	for i, w := range writes {
+		if w != res[i] {
+			t.Errorf("WithWriterFunc() writes[%d] = \"%v\", want \"%v\".", i, w, res[i])
+		}
+	}
+}
+
+func TestWithPrefixFunc(t *testing.T) {
+	prefix := func(lv Level) string {
+		if lv >= ERROR {
+			return "!! "
+		}
+		return "-- "
+	}
+	testCase := []struct {
+		f func(Level) string
+		s string
+	}{
+		{f: nil, s: "static [INFO] hello\nstatic [ERROR] world\n"},
+		{f: prefix, s: "-- [INFO] hello\n!! [ERROR] world\n"},
+	}
+	for _, tst := range testCase {
+		buf := &bytes.Buffer{}
+		l := New(WithWriter(buf), WithFlags(Llevel), WithPrefix("static "), WithPrefixFunc(tst.f))
+		l.Print("hello")
+		l.Error("world")
+		if str := buf.String(); str != tst.s {
+			t.Errorf("WithPrefixFunc()  = \"%v\", want \"%v\".", str, tst.s)
+		}
+	}
+}
+
+func TestWithPrefixFuncFormatter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFormatter(testFormatter{}), WithPrefixFunc(func(lv Level) string { return lv.String() + ">" }))
+	l.Warn("hello")
+	res := "WARN>WARN|hello\n"
+	if str := buf.String(); str != res {
+		t.Errorf("WithPrefixFunc()  = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+func TestWithCaller(t *testing.T) {
+	testCase := []struct {
+		flag   int
+		caller bool
+		res    int
+	}{
+		{flag: LstdFlags, caller: true, res: LstdFlags | Lshortfile},
+		{flag: LstdFlags | Lshortfile, caller: true, res: LstdFlags | Lshortfile},
+		{flag: LstdFlags | Lshortfile, caller: false, res: LstdFlags},
+		{flag: LstdFlags | Llongfile, caller: false, res: LstdFlags},
+		{flag: Ldate | LUTC | Llongfile | Lshortfile, caller: false, res: Ldate | LUTC},
+	}
+	for _, tst := range testCase {
+		l := New(WithFlags(tst.flag), WithCaller(tst.caller))
+		if flag := l.Flags(); flag != tst.res {
+			t.Errorf("WithCaller(%v) flags = %#x, want %#x.", tst.caller, flag, tst.res)
+		}
+	}
+}
+
+func TestQuietVerbose(t *testing.T) {
+	testCase := []struct {
+		f  func(*Logger)
+		lv Level
+	}{
+		{f: (*Logger).Quiet, lv: ERROR},
+		{f: (*Logger).Verbose, lv: DEBUG},
+		{f: (*Logger).VeryVerbose, lv: TRACE},
+	}
+	for _, tst := range testCase {
+		l := New(WithMinLevel(INFO))
+		tst.f(l)
+		if lv := l.MinLevel(); lv != tst.lv {
+			t.Errorf("Logger min level = %v, want %v.", lv, tst.lv)
+		}
+	}
+	defer SetMinLevel(MinLevel())
+	for _, tst := range []struct {
+		f  func()
+		lv Level
+	}{{f: Quiet, lv: ERROR}, {f: Verbose, lv: DEBUG}, {f: VeryVerbose, lv: TRACE}} {
+		tst.f()
+		if lv := MinLevel(); lv != tst.lv {
+			t.Errorf("std min level = %v, want %v.", lv, tst.lv)
+		}
+	}
+}
+
+func TestFiltered(t *testing.T) {
+	l := New(WithWriter(new(bytes.Buffer)), WithMinLevel(WARN))
+	child := l.WithFields(Fields{"a": 1})
+	l.Print("filtered")
+	child.Print("filtered")
+	_ = l.Output(DEBUG, 2, "filtered")
+	l.Warn("emitted")
+	l.Error("emitted")
+	if n := l.Filtered(); n != 3 {
+		t.Errorf("Logger.Filtered() = %d, want 3.", n)
+	}
+}
+
+func TestWithCallerOnLevel(t *testing.T) {
+	testCase := []struct {
+		flag int
+		s    string
+	}{
+		{flag: Llevel, s: "[INFO] info\noptions_test.go:247: [ERROR] error\n"},
+		{flag: Llevel | Lshortfile, s: "[INFO] info\noptions_test.go:247: [ERROR] error\n"},
+	}
+	for _, tst := range testCase {
+		buf := new(bytes.Buffer)
+		l := New(WithWriter(buf), WithFlags(tst.flag), WithCallerOnLevel(ERROR))
+		l.Print("info")
+		l.Error("error")
+		if s := buf.String(); s != tst.s {
+			t.Errorf("WithCallerOnLevel()  = \"%v\", want \"%v\".", s, tst.s)
+		}
+	}
+}
+
+func TestGetLoggerCoherence(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithFlags(LstdFlags))
+	lg := l.GetLogger()
+	lg.SetFlags(Lshortfile)
+	lg.SetOutput(buf)
+	lg.SetPrefix("[EXT] ")
+	if flag := l.Flags(); flag != Llevel|Lshortfile {
+		t.Errorf("Logger.Flags() = %#x, want %#x.", flag, Llevel|Lshortfile)
+	}
+	l.Print("hello")
+	res := "[EXT] options_test.go:264: [INFO] hello\n"
+	if s := buf.String(); s != res {
+		t.Errorf("Logger output = \"%v\", want \"%v\".", s, res)
+	}
+	//Llevel is not passed to log.Logger (Lmsgprefix in log package)
+	if flag := lg.Flags(); flag != Lshortfile {
+		t.Errorf("log.Logger.Flags() = %#x, want %#x.", flag, Lshortfile)
+	}
+}
+
+func TestWithLevelChangeHook(t *testing.T) {
+	type change struct{ old, new Level }
+	var got []change
+	l := New(WithMinLevel(INFO), WithLevelChangeHook(func(old, new Level) { got = append(got, change{old: old, new: new}) }))
+	l.SetMinLevel(DEBUG)
+	l.Quiet()
+	want := []change{{old: INFO, new: DEBUG}, {old: DEBUG, new: ERROR}}
+	if len(got) != len(want) {
+		t.Fatalf("level change hook = %v, want %v.", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("level change hook = %v, want %v.", got[i], want[i])
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
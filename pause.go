@@ -0,0 +1,53 @@
+package logf
+
+import "sync/atomic"
+
+//Pause makes the logger drop all logging events until Resume is called
+//(e.g. in noisy maintenance windows). The minimum level is not changed.
+func (l *Logger) Pause() { atomic.StoreInt32(&l.paused, 1) }
+
+//Resume makes the logger print logging events again after Pause.
+func (l *Logger) Resume() { atomic.StoreInt32(&l.paused, 0) }
+
+//Paused returns true if the logger is paused.
+func (l *Logger) Paused() bool { return atomic.LoadInt32(&l.paused) != 0 }
+
+//Dropped returns count of logging events dropped while the logger is paused
+//(each line of Block method is counted as an event).
+func (l *Logger) Dropped() uint64 { return atomic.LoadUint64(&l.dropped) }
+
+//drop reports whether the logger is paused and counts n events dropped if so.
+func (l *Logger) drop(n int) bool {
+	if atomic.LoadInt32(&l.paused) == 0 {
+		return false
+	}
+	atomic.AddUint64(&l.dropped, uint64(n))
+	return true
+}
+
+//Pause calls std.Pause() to pause the logger.
+func Pause() { std.Pause() }
+
+//Resume calls std.Resume() to resume the logger.
+func Resume() { std.Resume() }
+
+//Paused calls std.Paused() to check whether the logger is paused.
+func Paused() bool { return std.Paused() }
+
+//Dropped calls std.Dropped() to get count of events dropped while paused.
+func Dropped() uint64 { return std.Dropped() }
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,55 @@
+package logf
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestPause(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel), WithMinLevel(INFO))
+	l.Print("before")
+	l.Pause()
+	if !l.Paused() {
+		t.Error("Paused() = false, want true.")
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Print("paused")
+			l.WithFields(Fields{"id": 1}).Infow("paused", nil)
+			_ = l.Block(INFO, "paused 1", "paused 2")
+			_ = l.Fprintf(buf, INFO, "paused")
+		}()
+	}
+	wg.Wait()
+	l.Resume()
+	l.Print("after")
+	if s, want := buf.String(), "[INFO] before\n[INFO] after\n"; s != want {
+		t.Errorf("output = \"%v\", want \"%v\".", s, want)
+	}
+	if n := l.Dropped(); n != 20 {
+		t.Errorf("Dropped() = %d, want 20.", n)
+	}
+	if l.Paused() || l.MinLevel() != INFO {
+		t.Errorf("Paused() = %v, MinLevel() = %v, want false, INFO.", l.Paused(), l.MinLevel())
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,37 @@
+package logf
+
+//Raw writes s verbatim (with trailing newline) to the output of the logger,
+//without prefix, time stamp, level and so on (e.g. pre-formatted banner or line of external tool).
+//It is serialized with other logging events of the logger.
+func (l *Logger) Raw(s string) error {
+	b := []byte(s)
+	if len(b) == 0 || b[len(b)-1] != '\n' {
+		b = append(b, '\n')
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.shared != nil {
+		l.shared.Lock()
+		defer l.shared.Unlock()
+	}
+	_, err := l.lg.Writer().Write(b)
+	return err
+}
+
+//Raw calls std.Raw() to write s verbatim.
+func Raw(s string) error { return std.Raw(s) }
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,73 @@
+package logf
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRaw(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(LstdFlags|Lshortfile), WithPrefix("app: "), WithFormat(JSON))
+	if err := l.Raw("=== banner ==="); err != nil {
+		t.Errorf("Raw() = \"%v\", want nil.", err)
+	}
+	l.Raw("line\n")
+	l.Raw("")
+	if s, want := buf.String(), "=== banner ===\nline\n\n"; s != want {
+		t.Errorf("Raw() = \"%v\", want \"%v\".", s, want)
+	}
+}
+
+func TestRawConcurrency(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriterFunc(func(p []byte) (int, error) {
+		n, _ := buf.Write(p[:len(p)/2])
+		runtime.Gosched() //let other writers run into the critical section
+		m, err := buf.Write(p[len(p)/2:])
+		return n + m, err
+	}), WithFlags(Llevel))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				_ = l.Raw("raw")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				l.Print("other")
+			}
+		}()
+	}
+	wg.Wait()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4*1000*2 {
+		t.Fatalf("Raw() wrote %d lines, want %d.", len(lines), 4*1000*2)
+	}
+	for i, line := range lines {
+		if line != "raw" && line != "[INFO] other" {
+			t.Fatalf("Raw() is interleaved at line %d: %q", i, line)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
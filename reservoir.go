@@ -0,0 +1,147 @@
+package logf
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+//reservoir keeps random subset of events at a level in current time window (reservoir sampling).
+type reservoir struct {
+	mu     sync.Mutex
+	k      int           // size of subset
+	window time.Duration // length of window
+	rnd    *rand.Rand    // random number generator
+	start  time.Time     // start of window (zero if no candidates)
+	seen   int           // number of candidates in window
+	events []reservedEvent
+}
+
+//reservedEvent is selected event with sequence number in window.
+type reservedEvent struct {
+	seq int
+	burstEvent
+}
+
+//WithReservoirSampling returns function for sampling events at level lv by reservoir sampling:
+//events are buffered and k of them in each window are selected at random (every event has the same chance),
+//then the selected events are emitted in order of arrival when the window ends
+//(checked at next event, Sync() or Close()). If k is 0 or less, or window is 0 or less, it is disabled for lv.
+func WithReservoirSampling(lv Level, k int, window time.Duration) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if k <= 0 || window <= 0 {
+			delete(l.reservoirs, lv)
+			return
+		}
+		if l.reservoirs == nil {
+			l.reservoirs = map[Level]*reservoir{}
+		}
+		l.reservoirs[lv] = &reservoir{k: k, window: window, rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	}
+}
+
+//reserve reports whether the event is emitted immediately.
+//Events at level of reservoir sampling are buffered (returns false),
+//and the selected events of windows which ended are written first.
+func (l *Logger) reserve(lv Level, calldepth int, s string, fields []Field) bool {
+	l.mu.Lock()
+	if len(l.reservoirs) == 0 {
+		l.mu.Unlock()
+		return true
+	}
+	now := l.clock()
+	levels := l.reservoirLevels()
+	rs := make([]*reservoir, 0, len(levels))
+	for _, v := range levels {
+		rs = append(rs, l.reservoirs[v])
+	}
+	r := l.reservoirs[lv]
+	l.mu.Unlock()
+	for _, res := range rs {
+		l.writeReserved(calldepth+1, res.take(now, false))
+	}
+	if r == nil {
+		return true
+	}
+	r.add(now, burstEvent{lv: lv, s: s, fields: append([]Field{}, fields...)})
+	return false
+}
+
+//flushReservoirs writes the selected events of current windows.
+func (l *Logger) flushReservoirs() {
+	l.mu.Lock()
+	rs := make([]*reservoir, 0, len(l.reservoirs))
+	for _, v := range l.reservoirLevels() {
+		rs = append(rs, l.reservoirs[v])
+	}
+	l.mu.Unlock()
+	for _, r := range rs {
+		l.writeReserved(2, r.take(time.Time{}, true))
+	}
+}
+
+//reservoirLevels returns levels of reservoir sampling in ascending order.
+//It must be called with l.mu held.
+func (l *Logger) reservoirLevels() []Level {
+	levels := make([]Level, 0, len(l.reservoirs))
+	for v := range l.reservoirs {
+		levels = append(levels, v)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+	return levels
+}
+
+//writeReserved writes the selected events.
+func (l *Logger) writeReserved(calldepth int, events []reservedEvent) {
+	for _, ev := range events {
+		_ = l.dispatch(ev.lv, calldepth+1, ev.s, ev.fields)
+	}
+}
+
+//add adds candidate event (algorithm R).
+func (r *reservoir) add(now time.Time, ev burstEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.start.IsZero() {
+		r.start = now
+	}
+	r.seen++
+	if len(r.events) < r.k {
+		r.events = append(r.events, reservedEvent{seq: r.seen, burstEvent: ev})
+		return
+	}
+	if j := r.rnd.Intn(r.seen); j < r.k {
+		r.events[j] = reservedEvent{seq: r.seen, burstEvent: ev}
+	}
+}
+
+//take returns the selected events in order of arrival if the window ended (or force is true) and starts new window.
+func (r *reservoir) take(now time.Time, force bool) []reservedEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.start.IsZero() || (!force && now.Sub(r.start) < r.window) {
+		return nil
+	}
+	events := r.events
+	r.start, r.seen, r.events = time.Time{}, 0, nil
+	sort.Slice(events, func(i, j int) bool { return events[i].seq < events[j].seq })
+	return events
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,95 @@
+package logf
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithReservoirSampling(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel), WithMinLevel(TRACE), WithClock(func() time.Time { return now }), WithReservoirSampling(DEBUG, 3, time.Second))
+	l.reservoirs[DEBUG].rnd = rand.New(rand.NewSource(1))
+	for i := 1; i <= 10; i++ {
+		_ = l.Output(DEBUG, 2, fmt.Sprintf("tick %d", i))
+		now = now.Add(50 * time.Millisecond)
+	}
+	l.Print("info")
+	if s, res := buf.String(), "[INFO] info\n"; s != res {
+		t.Errorf("output in window = \"%v\", want \"%v\".", s, res)
+	}
+	buf.Reset()
+	now = now.Add(time.Second)
+	l.Print("next")
+	res := "[DEBUG] tick 5\n[DEBUG] tick 7\n[DEBUG] tick 8\n[INFO] next\n"
+	if s := buf.String(); s != res {
+		t.Errorf("output after window = \"%v\", want \"%v\".", s, res)
+	}
+
+	buf.Reset()
+	_ = l.Output(DEBUG, 2, "pending")
+	if err := l.Sync(); err != nil {
+		t.Errorf("Logger.Sync() = \"%v\", want nil.", err)
+	}
+	if s, res := buf.String(), "[DEBUG] pending\n"; s != res {
+		t.Errorf("output after Sync() = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestWithReservoirSamplingDisabled(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(0), WithReservoirSampling(INFO, 2, time.Second), WithReservoirSampling(INFO, 0, time.Second))
+	for i := 0; i < 3; i++ {
+		l.Print("tick")
+	}
+	if n := strings.Count(buf.String(), "tick"); n != 3 {
+		t.Errorf("count of \"tick\" = %d, want 3.", n)
+	}
+}
+
+func TestPending(t *testing.T) {
+	ch := make(chan string, 4)
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(0), WithMinLevel(TRACE), WithChannel(ch), WithReservoirSampling(DEBUG, 2, time.Hour))
+	for i := 0; i < 3; i++ {
+		_ = l.Output(DEBUG, 2, "tick")
+	}
+	if n := l.Pending(); n != 2 {
+		t.Errorf("Logger.Pending() = %d, want 2.", n)
+	}
+	l.Print("info")
+	if n := l.Pending(); n != 3 {
+		t.Errorf("Logger.Pending() = %d, want 3.", n)
+	}
+	if err := l.Sync(); err != nil {
+		t.Errorf("Logger.Sync() = \"%v\", want nil.", err)
+	}
+	if n := l.Pending(); n != 3 {
+		t.Errorf("Logger.Pending() after Sync() = %d, want 3 (in channel).", n)
+	}
+	for len(ch) > 0 {
+		<-ch
+	}
+	if n := l.Pending(); n != 0 {
+		t.Errorf("Logger.Pending() after receiving = %d, want 0.", n)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
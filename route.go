@@ -0,0 +1,49 @@
+package logf
+
+import "io"
+
+//levelRoute is writer for events in range of levels.
+type levelRoute struct {
+	min, max Level
+	w        io.Writer
+}
+
+//WithLevelRangeOutput returns function for writing events at level from min to max to w
+//instead of the output of Logger (e.g. WARN..FATAL to alert file).
+//If ranges overlap, the last registered one wins.
+func WithLevelRangeOutput(min, max Level, w io.Writer) OptFunc {
+	return func(l *Logger) {
+		if w == nil || min > max {
+			return
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.routes = append(l.routes, levelRoute{min: min, max: max, w: w})
+	}
+}
+
+//writerFor returns writer of event at level lv.
+//It must be called with l.mu held.
+func (l *Logger) writerFor(lv Level) io.Writer {
+	for i := len(l.routes) - 1; i >= 0; i-- {
+		if r := l.routes[i]; lv.GTE(r.min) && r.max.GTE(lv) {
+			return r.w
+		}
+	}
+	return l.lg.Writer()
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,64 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWithLevelRangeOutput(t *testing.T) {
+	out := &bytes.Buffer{}
+	alert := &bytes.Buffer{}
+	fatal := &bytes.Buffer{}
+	l := New(
+		WithWriter(out),
+		WithFlags(Llevel),
+		WithLevelRangeOutput(WARN, FATAL, alert),
+		WithLevelRangeOutput(FATAL, FATAL, fatal),
+	)
+	for _, lv := range Levels() {
+		_ = l.Output(lv, 1, "hello")
+	}
+	testCase := []struct {
+		name string
+		buf  *bytes.Buffer
+		s    string
+	}{
+		{name: "output", buf: out, s: "[TRACE] hello\n[DEBUG] hello\n[INFO] hello\n"},
+		{name: "alert", buf: alert, s: "[WARN] hello\n[ERROR] hello\n"},
+		{name: "fatal", buf: fatal, s: "[FATAL] hello\n"},
+	}
+	for _, tst := range testCase {
+		if s := tst.buf.String(); s != tst.s {
+			t.Errorf("%s = \"%v\", want \"%v\".", tst.name, s, tst.s)
+		}
+	}
+}
+
+func TestWithLevelRangeOutputJSON(t *testing.T) {
+	out := &bytes.Buffer{}
+	alert := &bytes.Buffer{}
+	l := New(WithWriter(out), WithFormatter(testFormatter{}), WithLevelRangeOutput(ERROR, FATAL, alert))
+	_ = l.Output(WARN, 1, "warn")
+	_ = l.Output(ERROR, 1, "error")
+	if s := out.String(); s != "WARN|warn\n" {
+		t.Errorf("output = \"%v\", want \"%v\".", s, "WARN|warn\n")
+	}
+	if s := alert.String(); s != "ERROR|error\n" {
+		t.Errorf("alert = \"%v\", want \"%v\".", s, "ERROR|error\n")
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
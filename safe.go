@@ -0,0 +1,156 @@
+package logf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//safeString returns result of fn.
+//If fn panics (e.g. in String() or Error() method of logged value),
+//it returns "%!PANIC(...)" marker instead of crashing the logger.
+func safeString(fn func() string) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = fmt.Sprintf("%%!PANIC(%v)", r)
+		}
+	}()
+	return fn()
+}
+
+//sprintf is panic-safe fmt.Sprintf.
+func sprintf(format string, v ...interface{}) string {
+	return safeString(func() string { return fmt.Sprintf(format, v...) })
+}
+
+//WithMissingArgHandler returns function for setting a handler called when arguments of format are missing
+//(fmt renders "%!verb(MISSING)"). got is the number of given arguments.
+func WithMissingArgHandler(fn func(format string, got int)) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.missingArg = fn
+	}
+}
+
+//sprintf is panic-safe fmt.Sprintf which reports missing arguments to the handler of WithMissingArgHandler option.
+func (l *Logger) sprintf(format string, v ...interface{}) string {
+	s := sprintf(format, v...)
+	l.mu.Lock()
+	fn := l.missingArg
+	l.mu.Unlock()
+	if fn != nil && strings.Contains(s, "(MISSING)") {
+		fn(format, len(v))
+	}
+	return s
+}
+
+//sprint is panic-safe fmt.Sprint.
+func sprint(v ...interface{}) string {
+	return safeString(func() string { return fmt.Sprint(v...) })
+}
+
+//sprintln is panic-safe fmt.Sprintln.
+func sprintln(v ...interface{}) string {
+	return safeString(func() string { return fmt.Sprintln(v...) })
+}
+
+//Safef prints at level lv to the logger, treating format as constant and v as data.
+//If format needs more arguments than v (e.g. format is untrusted input with verbs),
+//the message is "!UNSAFE" marker with quoted format and arguments instead.
+func (l *Logger) Safef(lv Level, format string, v ...interface{}) { l.lsafef(lv, format, v...) }
+
+//Safef calls std.Safef() to print to the logger.
+func Safef(lv Level, format string, v ...interface{}) { std.lsafef(lv, format, v...) }
+
+//lsafef calls l.Output() to print to the logger and returns the message.
+func (l *Logger) lsafef(lv Level, format string, v ...interface{}) string {
+	var s string
+	if countArgs(format) > len(v) {
+		s = "!UNSAFE(" + strconv.Quote(format) + ")"
+		if len(v) > 0 {
+			s += " " + trimNewline(sprintln(v...))
+		}
+	} else {
+		s = trimNewline(sprintf(format, v...))
+	}
+	_ = l.Output(lv, l.depth, s)
+	return s
+}
+
+//countArgs returns number of arguments needed by format (including '*' width and precision).
+func countArgs(format string) int {
+	need, argNum := 0, 0
+	use := func() {
+		if argNum++; argNum > need {
+			need = argNum
+		}
+	}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		//flags
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		i = argIndex(format, i, &argNum)
+		//width
+		if i < len(format) && format[i] == '*' {
+			use()
+			i++
+		}
+		for i < len(format) && '0' <= format[i] && format[i] <= '9' {
+			i++
+		}
+		//precision
+		if i < len(format) && format[i] == '.' {
+			i++
+			i = argIndex(format, i, &argNum)
+			if i < len(format) && format[i] == '*' {
+				use()
+				i++
+			}
+			for i < len(format) && '0' <= format[i] && format[i] <= '9' {
+				i++
+			}
+		}
+		i = argIndex(format, i, &argNum)
+		if i >= len(format) || format[i] == '%' {
+			continue
+		}
+		use()
+	}
+	return need
+}
+
+//argIndex parses explicit argument index ("[n]") at format[i] and returns next position.
+func argIndex(format string, i int, argNum *int) int {
+	if i >= len(format) || format[i] != '[' {
+		return i
+	}
+	j := strings.IndexByte(format[i:], ']')
+	if j < 0 {
+		return i
+	}
+	if n, err := strconv.Atoi(format[i+1 : i+j]); err == nil && n > 0 {
+		*argNum = n - 1
+	}
+	return i + j + 1
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,138 @@
+package logf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type panicStringer struct{}
+
+func (p panicStringer) String() string { panic("boom") }
+
+type panicError struct{}
+
+func (p panicError) Error() string { panic("boom") }
+
+func TestSafeString(t *testing.T) {
+	res := "%!PANIC(boom)"
+	if s := safeString(func() string { panic("boom") }); s != res {
+		t.Errorf("safeString()  = \"%v\", want \"%v\".", s, res)
+	}
+	if s := safeString(func() string { return "ok" }); s != "ok" {
+		t.Errorf("safeString()  = \"%v\", want \"%v\".", s, "ok")
+	}
+}
+
+func TestPanicInStringer(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel),
+	)
+	l.Print(panicStringer{})
+	l.Printf("%v", panicError{})
+	l.Infot("value: {v}", Fields{"v": panicError{}})
+	lines := strings.Split(strings.TrimSuffix(outBuf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Logger output = \"%v\", want 3 lines.", outBuf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "PANIC") {
+			t.Errorf("Logger output = \"%v\", want PANIC marker.", line)
+		}
+	}
+}
+
+func TestPanicInFieldValue(t *testing.T) {
+	tm := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	res := `{"time":"2009-11-10T23:00:00Z","level":"ERROR","msg":"Erroring","err":"%!PANIC(boom)"}` + "\n"
+	if s := string(JSONFormatter{}.FormatFields(ERROR, "", tm, "Erroring", []Field{{Key: "err", Value: panicError{}}})); s != res {
+		t.Errorf("JSONFormatter.FormatFields()  = \"%v\", want \"%v\".", s, res)
+	}
+	res = "time=2009-11-10T23:00:00Z level=ERROR msg=Erroring err=%!PANIC(boom)\n"
+	if s := string(LogfmtFormatter{}.FormatFields(ERROR, "", tm, "Erroring", []Field{{Key: "err", Value: panicError{}}})); s != res {
+		t.Errorf("LogfmtFormatter.FormatFields()  = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+func TestCountArgs(t *testing.T) {
+	testCase := []struct {
+		format string
+		n      int
+	}{
+		{format: "plain", n: 0},
+		{format: "100%%", n: 0},
+		{format: "%d %s", n: 2},
+		{format: "%-8.3f|%+v|%#x", n: 3},
+		{format: "%*d", n: 2},
+		{format: "%.*f", n: 2},
+		{format: "%[2]d %[1]d", n: 2},
+		{format: "%[3]v", n: 3},
+		{format: "trailing %", n: 0},
+	}
+	for _, tst := range testCase {
+		if n := countArgs(tst.format); n != tst.n {
+			t.Errorf("countArgs(%q) = %d, want %d.", tst.format, n, tst.n)
+		}
+	}
+}
+
+func TestSafef(t *testing.T) {
+	testCase := []struct {
+		format string
+		v      []interface{}
+		s      string
+	}{
+		{format: "user %s logged in", v: []interface{}{"alice"}, s: "[WARN] user alice logged in\n"},
+		{format: "100%% done", s: "[WARN] 100% done\n"},
+		{format: "user input %s%n%x", s: "[WARN] !UNSAFE(\"user input %s%n%x\")\n"},
+		{format: "id=%d name=%s", v: []interface{}{1}, s: "[WARN] !UNSAFE(\"id=%d name=%s\") 1\n"},
+	}
+	for _, tst := range testCase {
+		outBuf := new(bytes.Buffer)
+		l := New(WithWriter(outBuf), WithFlags(Llevel))
+		l.Safef(WARN, tst.format, tst.v...)
+		if s := outBuf.String(); s != tst.s {
+			t.Errorf("Logger.Safef(%q) = \"%v\", want \"%v\".", tst.format, s, tst.s)
+		}
+	}
+}
+
+func TestWithMissingArgHandler(t *testing.T) {
+	var gotFormat string
+	gotArgs := -1
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel), WithMissingArgHandler(func(format string, got int) {
+		gotFormat, gotArgs = format, got
+	}))
+	format := "%s %s" // not constant for go vet
+	l.Printf(format, "a")
+	if gotFormat != "%s %s" || gotArgs != 1 {
+		t.Errorf("missing arg handler = (\"%v\", %d), want (\"%v\", %d).", gotFormat, gotArgs, "%s %s", 1)
+	}
+	if s := buf.String(); s != "[INFO] a %!s(MISSING)\n" {
+		t.Errorf("Printf() = \"%v\", want \"%v\".", s, "[INFO] a %!s(MISSING)\n")
+	}
+	gotFormat, gotArgs = "", -1
+	l.Printf("%s %s", "a", "b")
+	if gotArgs != -1 {
+		t.Errorf("missing arg handler called for \"%v\", want not called.", gotFormat)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
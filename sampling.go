@@ -0,0 +1,83 @@
+package logf
+
+import (
+	"sync"
+	"time"
+)
+
+//sampleTick is interval of resetting counts of sampler.
+const sampleTick = time.Second
+
+//sampler samples events by message (first N, then every M-th in each tick).
+type sampler struct {
+	mu         sync.Mutex
+	lv         Level
+	first      uint64
+	thereafter uint64
+	start      time.Time         // start of current tick
+	counts     map[string]uint64 // counts of messages in current tick
+}
+
+//WithSampleFirstN returns function for sampling events at level lv or lower.
+//For each message, the first "first" events in each second are emitted and then every "thereafter"-th event
+//(others are dropped). If thereafter is 0, all events after the first ones are dropped.
+//Counts are reset every second (checked at next event), so distinct messages do not accumulate.
+func WithSampleFirstN(lv Level, first int, thereafter int) OptFunc {
+	return func(l *Logger) {
+		if first < 0 {
+			first = 0
+		}
+		if thereafter < 0 {
+			thereafter = 0
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.sampler = &sampler{lv: lv, first: uint64(first), thereafter: uint64(thereafter), counts: map[string]uint64{}}
+	}
+}
+
+//sample reports whether the event should be emitted.
+func (l *Logger) sample(lv Level, msg string) bool {
+	l.mu.Lock()
+	s := l.sampler
+	var now time.Time
+	if s != nil {
+		now = l.clock()
+	}
+	l.mu.Unlock()
+	if s == nil || lv.Above(s.lv) {
+		return true
+	}
+	return s.allow(now, msg)
+}
+
+//allow counts msg at time now and reports whether it is emitted.
+func (s *sampler) allow(now time.Time, msg string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.start.IsZero() || now.Sub(s.start) >= sampleTick || now.Before(s.start) {
+		s.start = now
+		s.counts = map[string]uint64{}
+	}
+	n := s.counts[msg] + 1
+	s.counts[msg] = n
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
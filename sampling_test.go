@@ -0,0 +1,79 @@
+package logf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithSampleFirstN(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(0), WithSampleFirstN(WARN, 3, 4))
+	for i := 1; i <= 12; i++ {
+		l.Printf("tick %d", i)
+		l.Print("tick")
+		l.Error("error")
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	counts := map[string]int{}
+	for _, line := range lines {
+		counts[line]++
+	}
+	//"tick": 1, 2, 3 (always), 7, 11 (every 4th)
+	if counts["tick"] != 5 {
+		t.Errorf("sampled count of \"tick\" = %d, want 5.", counts["tick"])
+	}
+	//distinct messages are not sampled
+	if counts["tick 12"] != 1 {
+		t.Errorf("sampled count of \"tick 12\" = %d, want 1.", counts["tick 12"])
+	}
+	//events above the level are not sampled
+	if counts["error"] != 12 {
+		t.Errorf("sampled count of \"error\" = %d, want 12.", counts["error"])
+	}
+}
+
+func TestWithSampleFirstNDrop(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(0), WithSampleFirstN(INFO, 2, 0))
+	for i := 0; i < 5; i++ {
+		l.Print("tick")
+	}
+	if s := buf.String(); s != "tick\ntick\n" {
+		t.Errorf("WithSampleFirstN() = \"%v\", want \"%v\".", s, "tick\ntick\n")
+	}
+}
+
+func TestWithSampleFirstNTick(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(0), WithClock(func() time.Time { return now }), WithSampleFirstN(INFO, 1, 0))
+	for i := 0; i < 3; i++ {
+		l.Print("tick")
+		l.Printf("id %d", i)
+	}
+	now = now.Add(time.Second)
+	l.Print("tick")
+	if s, res := buf.String(), "tick\nid 0\nid 1\nid 2\ntick\n"; s != res {
+		t.Errorf("WithSampleFirstN() = \"%v\", want \"%v\".", s, res)
+	}
+	if n := len(l.sampler.counts); n != 1 {
+		t.Errorf("count of sampled messages = %d, want 1.", n)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,66 @@
+package logf
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//byteWriter writes each byte separately (so that unserialized writes interleave mid-line).
+type byteWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *byteWriter) Write(p []byte) (int, error) {
+	for _, c := range p {
+		w.buf.WriteByte(c)
+		runtime.Gosched()
+	}
+	return len(p), nil
+}
+
+func TestWithSharedMutex(t *testing.T) {
+	mu := &sync.Mutex{}
+	w := &byteWriter{}
+	loggers := []*Logger{
+		New(WithWriter(w), WithFlags(Llevel), WithSharedMutex(mu)),
+		New(WithWriter(w), WithFlags(Llevel), WithFormat(LOGFMT), WithSharedMutex(mu)),
+	}
+	wg := sync.WaitGroup{}
+	for i, l := range loggers {
+		wg.Add(1)
+		go func(l *Logger, msg string) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				l.Print(msg)
+			}
+		}(l, strings.Repeat(string(rune('a'+i)), 32))
+	}
+	wg.Wait()
+	lines := strings.Split(strings.TrimSuffix(w.buf.String(), "\n"), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("WithSharedMutex() writes %d lines, want 100.", len(lines))
+	}
+	for _, line := range lines {
+		if line != "[INFO] "+strings.Repeat("a", 32) && !strings.HasSuffix(line, " level=INFO msg="+strings.Repeat("b", 32)) {
+			t.Errorf("WithSharedMutex() line = \"%v\", want not interleaved.", line)
+		}
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,73 @@
+package logf
+
+import "io"
+
+//sink is an additional output of Logger.
+type sink struct {
+	formatter Formatter // nil means text format
+	inherit   bool      // use formatter of Logger instead
+	min       Level     // minimum level of the sink
+	w         io.Writer
+}
+
+//WithSink returns function for adding an additional output.
+//Every logging event is also written to w in format f,
+//besides the output of Logger.
+func WithSink(f Format, w io.Writer) OptFunc {
+	return WithSinkMinLevel(f, w, TRACE)
+}
+
+//WithSinkMinLevel returns function for adding an additional output with its own minimum level.
+//Logging events at min or higher are also written to w in format f.
+func WithSinkMinLevel(f Format, w io.Writer, min Level) OptFunc {
+	return func(l *Logger) {
+		if w == nil {
+			return
+		}
+		l.mu.Lock()
+		l.sinks = append(l.sinks, &sink{formatter: f.formatter(), min: min, w: w})
+		l.mu.Unlock()
+	}
+}
+
+//writeSinks writes a logging event to each sink of l.
+//calldepth is the same as l.lg.Output().
+func (l *Logger) writeSinks(lv Level, calldepth int, s string, fields []Field) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var err error
+	for _, sk := range l.sinks {
+		if !lv.GTE(sk.min) {
+			continue
+		}
+		f := sk.formatter
+		if sk.inherit {
+			f = l.formatter
+		}
+		var b []byte
+		if f != nil {
+			b = l.formatBytes(f, lv, calldepth, s, fields)
+		} else {
+			b = l.textBytes(lv, calldepth, s+l.renderFields(fields), l.clock(), l.textFlag(lv), l.colorEnabledFor(sk.w), "")
+		}
+		if e := l.writeTo(sk.w, lv, b); err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,73 @@
+package logf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWithSink(t *testing.T) {
+	txt := &bytes.Buffer{}
+	jsn := &bytes.Buffer{}
+	l := New(WithWriter(txt), WithFlags(Llevel), WithSink(JSON, jsn), WithSink(JSON, nil))
+	if err := l.OutputFields(INFO, 2, "hello", Fields{"id": 1}); err != nil {
+		t.Errorf("OutputFields() = \"%v\", want nil.", err)
+	}
+	if got, want := txt.String(), "[INFO] hello id=1\n"; got != want {
+		t.Errorf("text output = \"%v\", want \"%v\".", got, want)
+	}
+	for _, s := range []string{"\"level\":\"INFO\"", "\"msg\":\"hello\"", "\"id\":1"} {
+		if !strings.Contains(jsn.String(), s) {
+			t.Errorf("JSON sink = \"%v\", want containing \"%v\".", jsn.String(), s)
+		}
+	}
+}
+
+func TestWithSinkText(t *testing.T) {
+	jsn := &bytes.Buffer{}
+	txt := &bytes.Buffer{}
+	l := New(WithWriter(jsn), WithFlags(Llevel|Lshortfile), WithFormat(JSON), WithSink(TEXT, txt))
+	l.Output(WARN, 2, "hello")
+	if got, want := txt.String(), "sink_test.go:30: [WARN] hello\n"; got != want {
+		t.Errorf("text sink = \"%v\", want \"%v\".", got, want)
+	}
+	if !strings.Contains(jsn.String(), "\"msg\":\"hello\"") {
+		t.Errorf("JSON output = \"%v\", want containing msg.", jsn.String())
+	}
+}
+
+func TestWithSinkMinLevel(t *testing.T) {
+	console := &bytes.Buffer{}
+	file := &bytes.Buffer{}
+	l := New(
+		WithWriter(&bytes.Buffer{}),
+		WithFlags(Llevel),
+		WithSinkMinLevel(TEXT, console, INFO),
+		WithSinkMinLevel(JSON, file, DEBUG),
+	)
+	l.Output(TRACE, 2, "trace")
+	l.Output(DEBUG, 2, "debug")
+	l.Output(INFO, 2, "info")
+	if got, want := console.String(), "[INFO] info\n"; got != want {
+		t.Errorf("console sink = \"%v\", want \"%v\".", got, want)
+	}
+	s := file.String()
+	if strings.Contains(s, "\"msg\":\"trace\"") || !strings.Contains(s, "\"msg\":\"debug\"") || !strings.Contains(s, "\"msg\":\"info\"") {
+		t.Errorf("file sink = \"%v\", want DEBUG and INFO events.", s)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
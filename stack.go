@@ -0,0 +1,75 @@
+package logf
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+//maxStackFrames is maximum number of frames in stack field.
+const maxStackFrames = 32
+
+//stackTrace is value of stack field (one element per frame).
+//It is rendered as array in JSON format and as newline-joined block in text format.
+type stackTrace []string
+
+//WithStackField returns function for attaching "stack" field of call stack to events at level lv or higher.
+//Each frame is rendered as "function file:line" beginning with the caller of logging function.
+func WithStackField(lv Level) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.stackLevel = &lv
+	}
+}
+
+//stackFields returns fields with stack field appended if event at level lv needs it.
+//calldepth is the same as l.lg.Output().
+func (l *Logger) stackFields(lv Level, calldepth int, fields []Field) []Field {
+	l.mu.Lock()
+	min := l.stackLevel
+	l.mu.Unlock()
+	if min == nil || !lv.GTE(*min) {
+		return fields
+	}
+	return append(fields[:len(fields):len(fields)], Field{Key: "stack", Value: callStack(calldepth + 1)})
+}
+
+//callStack returns frames of call stack.
+//calldepth is the same as runtime.Caller().
+func callStack(calldepth int) stackTrace {
+	pcs := make([]uintptr, maxStackFrames)
+	n := runtime.Callers(calldepth+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	st := stackTrace{}
+	for {
+		fr, more := frames.Next()
+		if fr.Function != "runtime.goexit" {
+			st = append(st, fr.Function+" "+fr.File+":"+strconv.Itoa(fr.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return st
+}
+
+//block returns frames as newline-joined block.
+func (st stackTrace) block() string {
+	return strings.Join(st, "\n")
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,68 @@
+package logf
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWithStackField(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFormat(JSON), WithStackField(ERROR))
+	l.Print("info")
+	l.Error("failed")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("WithStackField() output = \"%v\", want 2 lines.", buf.String())
+	}
+	if strings.Contains(lines[0], `"stack"`) {
+		t.Errorf("WithStackField() output = \"%v\", want no stack field.", lines[0])
+	}
+	obj := struct {
+		Msg   string        `json:"msg"`
+		Stack []interface{} `json:"stack"`
+	}{}
+	if err := json.Unmarshal([]byte(lines[1]), &obj); err != nil {
+		t.Fatalf("WithStackField() output = \"%v\", not JSON: %v", lines[1], err)
+	}
+	if obj.Msg != "failed" || len(obj.Stack) < 2 {
+		t.Fatalf("WithStackField() output = \"%v\", want stack array.", lines[1])
+	}
+	for _, fr := range obj.Stack {
+		if _, ok := fr.(string); !ok {
+			t.Errorf("stack frame = %v, want string.", fr)
+		}
+	}
+	if fr := obj.Stack[0].(string); !strings.HasPrefix(fr, "github.com/spiegel-im-spiegel/logf.TestWithStackField ") || !strings.HasSuffix(fr, "stack_test.go:14") {
+		t.Errorf("first stack frame = \"%v\", want caller of Logger.Error().", fr)
+	}
+}
+
+func TestWithStackFieldText(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel), WithStackField(WARN))
+	l.WithFields(Fields{"id": 1}).Warn("slow")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "[WARN] slow id=1" {
+		t.Errorf("WithStackField() first line = \"%v\", want \"[WARN] slow id=1\".", lines[0])
+	}
+	if len(lines) < 2 || !strings.HasPrefix(lines[1], "github.com/spiegel-im-spiegel/logf.TestWithStackFieldText ") || !strings.HasSuffix(lines[1], "stack_test.go:45") {
+		t.Errorf("WithStackField() output = \"%v\", want stack block.", buf.String())
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
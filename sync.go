@@ -0,0 +1,152 @@
+package logf
+
+import (
+	"errors"
+	"io"
+	"syscall"
+)
+
+//Syncer is interface of writer which commits written data to stable storage (e.g. *os.File).
+type Syncer interface {
+	Sync() error
+}
+
+//Flusher is interface of writer which buffers written data (e.g. *bufio.Writer).
+type Flusher interface {
+	Flush() error
+}
+
+//WithFlushOnLevel returns function for flushing the writer after each event at level lv or higher
+//(the writer of WithLevelRangeOutput option if the event is routed).
+//It works with writers implementing Flusher interface (e.g. *bufio.Writer); written data is not committed to stable storage
+//(call Sync method for that).
+func WithFlushOnLevel(lv Level) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.flushLevel = &lv
+	}
+}
+
+//Sync flushes buffered data and commits written data to stable storage
+//if the writer implements Flusher or Syncer interface (writers of WithLevelRangeOutput and WithSink options too).
+//Pending events of WithReservoirSampling option and summary of WithBurstSummary option are written before that.
+//For other writers (and files which cannot be synchronized, e.g. os.Stderr attached to pipe or terminal) it returns nil.
+func (l *Logger) Sync() error {
+	l.flushReservoirs()
+	l.flushBurst()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var err error
+	for _, w := range l.writers() {
+		if e := syncWriter(w); err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+//Sync calls std.Sync() to commit written data of the logger.
+func Sync() error {
+	return std.Sync()
+}
+
+//Pending returns number of events buffered but not written yet:
+//events in current windows of WithReservoirSampling option (written by Sync method or when the window ends)
+//and events in channel of WithChannel option which are not received yet.
+func (l *Logger) Pending() int {
+	l.mu.Lock()
+	n := 0
+	for _, r := range l.reservoirs {
+		r.mu.Lock()
+		n += len(r.events)
+		r.mu.Unlock()
+	}
+	if l.chw != nil {
+		n += len(l.chw.ch)
+	}
+	l.mu.Unlock()
+	return n
+}
+
+//Pending calls std.Pending() to return number of buffered events.
+func Pending() int {
+	return std.Pending()
+}
+
+//flush writes pending events of WithReservoirSampling option and summary of WithBurstSummary option,
+//and flushes buffered data of the writers implementing Flusher interface (e.g. before panic or close).
+func (l *Logger) flush() error {
+	l.flushReservoirs()
+	l.flushBurst()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var err error
+	for _, w := range l.writers() {
+		if e := flushWriter(w); err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+//flushOnLevel flushes the writer if event at level lv is set by WithFlushOnLevel option.
+func (l *Logger) flushOnLevel(lv Level) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.flushLevel == nil || !lv.GTE(*l.flushLevel) {
+		return nil
+	}
+	return flushWriter(l.writerFor(lv))
+}
+
+//writers returns the output, writers of WithLevelRangeOutput option and writers of WithSink option.
+//It must be called with l.mu held.
+func (l *Logger) writers() []io.Writer {
+	ws := make([]io.Writer, 0, 1+len(l.routes)+len(l.sinks))
+	ws = append(ws, l.lg.Writer())
+	for _, r := range l.routes {
+		ws = append(ws, r.w)
+	}
+	for _, sk := range l.sinks {
+		ws = append(ws, sk.w)
+	}
+	return ws
+}
+
+//flushWriter flushes buffered data of w if w implements Flusher interface.
+func flushWriter(w io.Writer) error {
+	if f, ok := w.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+//syncWriter flushes buffered data of w and commits it to stable storage.
+//Files which cannot be synchronized (e.g. os.Stderr attached to pipe or terminal) are ignored.
+func syncWriter(w io.Writer) error {
+	if err := flushWriter(w); err != nil {
+		return err
+	}
+	if s, ok := w.(Syncer); ok {
+		if err := s.Sync(); err != nil && !errors.Is(err, syscall.EINVAL) && !errors.Is(err, syscall.ENOTSUP) {
+			return err
+		}
+	}
+	return nil
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
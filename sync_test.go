@@ -0,0 +1,188 @@
+package logf
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testSyncer struct {
+	bytes.Buffer
+	synced int
+}
+
+func (w *testSyncer) Sync() error {
+	w.synced++
+	return nil
+}
+
+func TestSync(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	l := New(WithWriter(file), WithFlags(Llevel))
+	l.Print("audit")
+	if err := l.Sync(); err != nil {
+		t.Errorf("Logger.Sync() = \"%v\", want nil.", err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); s != "[INFO] audit\n" {
+		t.Errorf("Logger.Sync() file = \"%v\", want \"%v\".", s, "[INFO] audit\n")
+	}
+}
+
+func TestSyncPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	l := New(WithWriter(w), WithFlags(Llevel))
+	l.Print("hello")
+	if err := l.Sync(); err != nil {
+		t.Errorf("Logger.Sync() = \"%v\", want nil.", err)
+	}
+}
+
+func TestSyncWriter(t *testing.T) {
+	w := &testSyncer{}
+	if err := New(WithWriter(w)).Sync(); err != nil || w.synced != 1 {
+		t.Errorf("Logger.Sync() = \"%v\" (synced %d), want nil (synced 1).", err, w.synced)
+	}
+	if err := New(WithWriter(&bytes.Buffer{})).Sync(); err != nil {
+		t.Errorf("Logger.Sync() = \"%v\", want nil.", err)
+	}
+}
+
+type testFlusher struct {
+	bytes.Buffer
+	flushed int
+}
+
+func (w *testFlusher) Flush() error {
+	w.flushed++
+	return nil
+}
+
+func TestFlusher(t *testing.T) {
+	w := &testFlusher{}
+	l := New(WithWriter(w))
+	if err := l.Sync(); err != nil || w.flushed != 1 {
+		t.Errorf("Logger.Sync() = \"%v\" (flushed %d), want nil (flushed 1).", err, w.flushed)
+	}
+	if err := l.Close(); err != nil || w.flushed != 2 {
+		t.Errorf("Logger.Close() = \"%v\" (flushed %d), want nil (flushed 2).", err, w.flushed)
+	}
+}
+
+func TestWithFlushOnLevel(t *testing.T) {
+	w := &testFlusher{}
+	l := New(WithWriter(w), WithFlags(Llevel), WithFlushOnLevel(ERROR))
+	l.Print("hello")
+	l.Output(WARN, 1, "hello")
+	if w.flushed != 0 {
+		t.Errorf("WithFlushOnLevel(ERROR) flushed %d times, want 0.", w.flushed)
+	}
+	l.Output(ERROR, 1, "hello")
+	if w.flushed != 1 {
+		t.Errorf("WithFlushOnLevel(ERROR) flushed %d times, want 1.", w.flushed)
+	}
+}
+
+func TestWithFlushOnLevelPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	var errs []error
+	l := New(WithWriter(w), WithFlags(Llevel), WithFlushOnLevel(ERROR), WithErrorHandler(func(err error) { errs = append(errs, err) }))
+	if err := l.Output(ERROR, 1, "hello"); err != nil {
+		t.Errorf("WithFlushOnLevel(ERROR) output = \"%v\", want nil.", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("WithFlushOnLevel(ERROR) errors = %v, want none.", errs)
+	}
+	b := make([]byte, 64)
+	n, _ := r.Read(b)
+	if s := string(b[:n]); s != "[ERROR] hello\n" {
+		t.Errorf("WithFlushOnLevel(ERROR) pipe = \"%v\", want \"%v\".", s, "[ERROR] hello\n")
+	}
+}
+
+func TestPanicFlush(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(bufio.NewWriter(buf)), WithFlags(Llevel))
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("Panic() panics with \"%v\", want \"boom\".", r)
+			}
+			if s := buf.String(); s != "[FATAL] boom\n" {
+				t.Errorf("Panic() output = \"%v\", want flushed \"[FATAL] boom\\n\".", s)
+			}
+		}()
+		l.Panic("boom")
+	}()
+}
+
+func TestPanicFlushRoute(t *testing.T) {
+	buf, alert := &bytes.Buffer{}, &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel), WithLevelRangeOutput(ERROR, FATAL, bufio.NewWriter(alert)))
+	l.Error("failed")
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("Panic() panics with \"%v\", want \"boom\".", r)
+			}
+			if s, res := alert.String(), "[ERROR] failed\n[FATAL] boom\n"; s != res {
+				t.Errorf("Panic() route = \"%v\", want flushed \"%v\".", s, res)
+			}
+		}()
+		l.Panic("boom")
+	}()
+}
+
+func TestWithFlushOnLevelRoute(t *testing.T) {
+	w, alert := &testFlusher{}, &testFlusher{}
+	l := New(WithWriter(w), WithFlags(Llevel), WithFlushOnLevel(ERROR), WithLevelRangeOutput(ERROR, FATAL, alert))
+	l.Output(ERROR, 1, "hello")
+	if w.flushed != 0 || alert.flushed != 1 {
+		t.Errorf("WithFlushOnLevel(ERROR) flushed %d and %d times, want 0 and 1.", w.flushed, alert.flushed)
+	}
+	if err := l.Sync(); err != nil || w.flushed != 1 || alert.flushed != 2 {
+		t.Errorf("Logger.Sync() = \"%v\" (flushed %d and %d), want nil (flushed 1 and 2).", err, w.flushed, alert.flushed)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,60 @@
+package logf
+
+//Tee duplicates every logging event of l into other logger and returns l.
+//It affects l and child loggers created from l after that (not the parent and siblings of l).
+//Each logger applies its own level filtering and formatting.
+//Structured fields of l are passed to other as they are (other adds its own fields, its prefix is not applied to them).
+//Tee to l itself (or a logger which tees to l) is ignored and ErrTeeLoop is reported to error handler.
+func (l *Logger) Tee(other *Logger) *Logger {
+	if other == nil {
+		return l
+	}
+	if other.reaches(l.core) {
+		l.handleError(ErrTeeLoop)
+		return l
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tees = append(append(make([]*Logger, 0, len(l.tees)+1), l.tees...), other)
+	return l
+}
+
+//teeLoggers returns secondary loggers (the slice is never modified, Tee method replaces it).
+func (l *Logger) teeLoggers() []*Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.tees
+}
+
+//reaches reports whether events of l reach c (including l itself).
+func (l *Logger) reaches(c *core) bool {
+	if l.core == c {
+		return true
+	}
+	for _, t := range l.teeLoggers() {
+		if t.reaches(c) {
+			return true
+		}
+	}
+	return false
+}
+
+//Tee duplicates every logging event of standard logger into other logger.
+func Tee(other *Logger) *Logger {
+	return std.Tee(other)
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
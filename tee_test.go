@@ -0,0 +1,95 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTee(t *testing.T) {
+	textBuf := &bytes.Buffer{}
+	jsonBuf := &bytes.Buffer{}
+	secondary := New(WithWriter(jsonBuf), WithFormatter(testFormatter{}), WithMinLevel(WARN))
+	l := New(WithWriter(textBuf), WithFlags(Llevel)).Tee(secondary)
+	l.Print("hello")
+	l.Error("world")
+	res := "[INFO] hello\n[ERROR] world\n"
+	if str := textBuf.String(); str != res {
+		t.Errorf("Tee() primary = \"%v\", want \"%v\".", str, res)
+	}
+	res2 := "ERROR|world\n"
+	if str := jsonBuf.String(); str != res2 {
+		t.Errorf("Tee() secondary = \"%v\", want \"%v\".", str, res2)
+	}
+}
+
+func TestTeeLoop(t *testing.T) {
+	var errs []error
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithFlags(Llevel), WithErrorHandler(func(err error) { errs = append(errs, err) }))
+	other := New(WithWriter(buf), WithFlags(Llevel)).Tee(l)
+	l.Tee(l).Tee(other).Tee(l.WithFields(Fields{"a": 1}))
+	l.Print("hello")
+	res := "[INFO] hello\n"
+	if str := buf.String(); str != res {
+		t.Errorf("Tee() = \"%v\", want \"%v\".", str, res)
+	}
+	if len(errs) != 3 {
+		t.Errorf("Tee() errors = %v, want 3 ErrTeeLoop.", errs)
+	}
+}
+
+func TestTeeFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	teeBuf := &bytes.Buffer{}
+	other := New(WithWriter(teeBuf), WithFlags(Llevel)).WithFieldPrefix("o.").WithFields(Fields{"id": 2})
+	l := New(WithWriter(buf), WithFlags(Llevel)).Tee(other).WithFieldPrefix("p.").WithFields(Fields{"k": 1})
+	l.Infow("hello", Fields{"n": 3})
+	if str, res := buf.String(), "[INFO] hello p.k=1 p.n=3\n"; str != res {
+		t.Errorf("Tee() primary = \"%v\", want \"%v\".", str, res)
+	}
+	if str, res := teeBuf.String(), "[INFO] hello o.id=2 p.k=1 p.n=3\n"; str != res {
+		t.Errorf("Tee() secondary = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+func TestTeeChild(t *testing.T) {
+	buf := &bytes.Buffer{}
+	teeBuf := &bytes.Buffer{}
+	parent := New(WithWriter(buf), WithFlags(Llevel))
+	sibling := parent.WithFields(Fields{"s": 1})
+	child := parent.WithFields(Fields{"c": 1}).Tee(New(WithWriter(teeBuf), WithFlags(Llevel)))
+	parent.Print("parent")
+	sibling.Print("sibling")
+	child.Print("child")
+	child.WithFields(Fields{"g": 1}).Print("grandchild")
+	if str, res := teeBuf.String(), "[INFO] child c=1\n[INFO] grandchild c=1 g=1\n"; str != res {
+		t.Errorf("Tee() of child = \"%v\", want \"%v\".", str, res)
+	}
+}
+
+func TestTeeLazy(t *testing.T) {
+	calls := 0
+	l := New(WithWriter(&bytes.Buffer{}), WithFlags(Llevel)).
+		Tee(New(WithWriter(&bytes.Buffer{}), WithFlags(Llevel))).
+		Tee(New(WithWriter(&bytes.Buffer{}), WithFlags(Llevel))).
+		WithLazyField("n", func() interface{} { calls++; return calls })
+	l.Print("hello")
+	if calls != 1 {
+		t.Errorf("lazy field with Tee() is evaluated %d times, want 1.", calls)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,83 @@
+package logf
+
+import "strings"
+
+//interpolate replaces {name} placeholders in tmpl with values of fields.
+//Placeholders of missing keys are left as is. "{{" and "}}" are literal braces.
+func interpolate(tmpl string, fields Fields) string {
+	buf := &strings.Builder{}
+	for i := 0; i < len(tmpl); i++ {
+		c := tmpl[i]
+		switch {
+		case c == '{' && i+1 < len(tmpl) && tmpl[i+1] == '{':
+			buf.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(tmpl) && tmpl[i+1] == '}':
+			buf.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexAny(tmpl[i+1:], "{}")
+			if end < 0 || tmpl[i+1+end] != '}' {
+				buf.WriteByte(c)
+				continue
+			}
+			name := tmpl[i+1 : i+1+end]
+			if v, ok := fields[name]; ok {
+				buf.WriteString(logfmtString(v))
+			} else {
+				buf.WriteString(tmpl[i : i+end+2])
+			}
+			i += end + 1
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
+
+//lprintt calls l.Output() to print to the logger and returns the message.
+//Message is made from template with named placeholders ({name}).
+func (l *Logger) lprintt(lv Level, tmpl string, fields Fields) string {
+	s := l.message(interpolate(tmpl, fields))
+	_ = l.Output(lv, l.depth, s)
+	return s
+}
+
+//Infot calls l.lprintt() to print to the logger.
+func (l *Logger) Infot(tmpl string, fields Fields) { l.lprintt(INFO, tmpl, fields) }
+
+//Warnt calls l.lprintt() to print to the logger.
+func (l *Logger) Warnt(tmpl string, fields Fields) { l.lprintt(WARN, tmpl, fields) }
+
+//Errort calls l.lprintt() to print to the logger.
+func (l *Logger) Errort(tmpl string, fields Fields) { l.lprintt(ERROR, tmpl, fields) }
+
+//Fatalt calls l.lprintt() to print to the logger, followed by fatal hooks and exit function.
+func (l *Logger) Fatalt(tmpl string, fields Fields) { l.fatal(l.lprintt(FATAL, tmpl, fields)) }
+
+//Infot calls std.Infot() to print to the logger.
+func Infot(tmpl string, fields Fields) { std.lprintt(INFO, tmpl, fields) }
+
+//Warnt calls std.Warnt() to print to the logger.
+func Warnt(tmpl string, fields Fields) { std.lprintt(WARN, tmpl, fields) }
+
+//Errort calls std.Errort() to print to the logger.
+func Errort(tmpl string, fields Fields) { std.lprintt(ERROR, tmpl, fields) }
+
+//Fatalt calls std.Fatalt() to print to the logger.
+func Fatalt(tmpl string, fields Fields) { std.fatal(std.lprintt(FATAL, tmpl, fields)) }
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
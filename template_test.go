@@ -0,0 +1,58 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	fields := Fields{"user": "alice", "ip": "192.0.2.1", "n": 3}
+	testCase := []struct {
+		tmpl string
+		s    string
+	}{
+		{tmpl: "user {user} logged in from {ip}", s: "user alice logged in from 192.0.2.1"},
+		{tmpl: "{n} times", s: "3 times"},
+		{tmpl: "user {name} logged in", s: "user {name} logged in"},
+		{tmpl: "{{user}} is literal", s: "{user} is literal"},
+		{tmpl: "{{{user}}}", s: "{alice}"},
+		{tmpl: "unclosed {user", s: "unclosed {user"},
+		{tmpl: "nested {us{user}", s: "nested {usalice"},
+		{tmpl: "empty {}", s: "empty {}"},
+		{tmpl: "} alone", s: "} alone"},
+	}
+	for _, tst := range testCase {
+		s := interpolate(tst.tmpl, fields)
+		if s != tst.s {
+			t.Errorf("interpolate(\"%s\")  = \"%v\", want \"%v\".", tst.tmpl, s, tst.s)
+		}
+	}
+}
+
+func TestInfot(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	l := New(
+		WithWriter(outBuf),
+		WithFlags(Llevel|Lshortfile),
+	)
+	l.Infot("user {user} logged in from {ip}", Fields{"user": "alice", "ip": "192.0.2.1"})
+	res := "template_test.go:38: [INFO] user alice logged in from 192.0.2.1\n"
+	if s := outBuf.String(); s != res {
+		t.Errorf("Logger.Infot()  = \"%v\", want \"%v\".", s, res)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,51 @@
+package logf
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+//testMode is 1 if test mode is enabled (accessed atomically).
+var testMode int32
+
+//ExitPanic is panic value of Fatal* functions in test mode.
+type ExitPanic struct {
+	Code int    // exit code
+	Msg  string // fatal message
+}
+
+func (e ExitPanic) Error() string {
+	return fmt.Sprintf("logf: exit(%d) in test mode: %s", e.Code, e.Msg)
+}
+
+//SetTestMode enables or disables test mode of all loggers.
+//In test mode, Fatal* functions panic with ExitPanic value instead of calling exit function,
+//so a stray Fatal does not kill the test binary and can be recovered for assertion.
+//Panic* functions panic as usual (recoverable). Loggers without exit (WithFatalNoExit option) are not affected.
+func SetTestMode(on bool) {
+	if on {
+		atomic.StoreInt32(&testMode, 1)
+	} else {
+		atomic.StoreInt32(&testMode, 0)
+	}
+}
+
+//inTestMode reports whether test mode is enabled.
+func inTestMode() bool {
+	return atomic.LoadInt32(&testMode) != 0
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
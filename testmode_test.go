@@ -0,0 +1,47 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSetTestMode(t *testing.T) {
+	SetTestMode(true)
+	defer SetTestMode(false)
+	exited := false
+	outBuf := new(bytes.Buffer)
+	l := New(WithWriter(outBuf), WithFlags(Llevel), WithExitFunc(func(int) { exited = true }))
+	func() {
+		defer func() {
+			r := recover()
+			e, ok := r.(ExitPanic)
+			if !ok || e.Code != 1 || e.Msg != "Fatal Erroring" {
+				t.Errorf("Logger.Fatal() in test mode panics with %#v, want ExitPanic.", r)
+			}
+		}()
+		l.Fatal("Fatal Erroring")
+	}()
+	if exited {
+		t.Error("Logger.Fatal() in test mode calls exit function.")
+	}
+	if s := outBuf.String(); s != "[FATAL] Fatal Erroring\n" {
+		t.Errorf("Logger.Fatal() = \"%v\", want \"%v\".", s, "[FATAL] Fatal Erroring\n")
+	}
+	//no exit, no panic
+	New(WithWriter(outBuf), WithFatalNoExit()).Fatal("Fatal Erroring")
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
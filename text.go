@@ -0,0 +1,197 @@
+package logf
+
+import (
+	"io"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//LevelWriter is io.Writer which receives level of logging event as well
+//(e.g. syslog or Windows event log). If the output of Logger implements
+//LevelWriter, Logger calls WriteLevel() instead of Write().
+type LevelWriter interface {
+	io.Writer
+	WriteLevel(lv Level, p []byte) (int, error)
+}
+
+//eventWriter is writer which receives structured logging event (e.g. journald).
+type eventWriter interface {
+	writeEvent(lv Level, msg string, fields []Field) error
+}
+
+//textHeader returns header of text format in the manner of log package
+//(prefix, date and time, file name and line number).
+func textHeader(prefix string, flag int, t time.Time, file string, line int) string {
+	buf := &strings.Builder{}
+	buf.WriteString(prefix)
+	if (flag & (Ldate | Ltime | Lmicroseconds)) != 0 {
+		if (flag & LUTC) != 0 {
+			t = t.UTC()
+		}
+		if (flag & Ldate) != 0 {
+			buf.WriteString(t.Format("2006/01/02 "))
+		}
+		if (flag & (Ltime | Lmicroseconds)) != 0 {
+			buf.WriteString(t.Format("15:04:05"))
+			if (flag & Lmicroseconds) != 0 {
+				buf.WriteString(t.Format(".000000"))
+			}
+			buf.WriteByte(' ')
+		}
+	}
+	if (flag & (Lshortfile | Llongfile)) != 0 {
+		if (flag & Lshortfile) != 0 {
+			file = filepath.Base(file)
+		}
+		buf.WriteString(file)
+		buf.WriteByte(':')
+		buf.WriteString(strconv.Itoa(line))
+		buf.WriteString(": ")
+	}
+	return buf.String()
+}
+
+//textFlag returns flags for event at level lv (file flags are changed by WithCallerOnLevel option).
+//It must be called with l.mu held.
+func (l *Logger) textFlag(lv Level) int {
+	flag := l.flags()
+	if l.callerLevel == nil {
+		return flag
+	}
+	if !lv.GTE(*l.callerLevel) {
+		return flag &^ (Lshortfile | Llongfile)
+	}
+	if (flag & Llongfile) == 0 {
+		flag |= Lshortfile
+	}
+	return flag
+}
+
+//formatText writes a logging event in text format without log.Logger.
+//calldepth is the same as l.lg.Output().
+func (l *Logger) formatText(lv Level, calldepth int, s string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t := l.clock()
+	flag := l.compactFlag(l.textFlag(lv), t)
+	return l.write(lv, l.textBytes(lv, calldepth+1, s, t, flag, l.colorEnabled(), l.nextDelta(t)))
+}
+
+//textBytes returns a logging event in text format.
+//It must be called with l.mu held.
+//delta is time since the previous event of WithDeltaTime option (empty if not printed).
+func (l *Logger) textBytes(lv Level, calldepth int, s string, t time.Time, flag int, color bool, delta string) []byte {
+	file, line := "???", 0
+	if (flag & (Lshortfile | Llongfile)) != 0 {
+		if _, f, n, ok := runtime.Caller(calldepth); ok {
+			file, line = f, n
+		}
+	}
+	prefix := l.prefix(lv)
+	if l.relative && (flag&(Ldate|Ltime|Lmicroseconds)) != 0 {
+		prefix += l.relativeTime(t)
+		flag &^= Ldate | Ltime | Lmicroseconds
+	}
+	b := []byte(textHeader(prefix, flag, t, file, line) + delta + l.header(lv, color) + s)
+	if len(b) == 0 || b[len(b)-1] != '\n' {
+		b = append(b, '\n')
+	}
+	return b
+}
+
+//writeEvent writes a structured logging event to eventWriter.
+func (l *Logger) writeEvent(w eventWriter, lv Level, s string, fields []Field) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return w.writeEvent(lv, l.prefix(lv)+s, append(l.staticFields(), fields...))
+}
+
+//write writes a formatted logging event to the output (or the writer routed by WithLevelRangeOutput option).
+//It must be called with l.mu held.
+func (l *Logger) write(lv Level, b []byte) error {
+	return l.writeTo(l.writerFor(lv), lv, b)
+}
+
+//writeTo writes a formatted logging event to w holding the mutex of WithSharedMutex option (if set).
+//It must be called with l.mu held.
+func (l *Logger) writeTo(w io.Writer, lv Level, b []byte) error {
+	if l.shared != nil {
+		l.shared.Lock()
+		defer l.shared.Unlock()
+	}
+	return writeLevel(w, lv, b)
+}
+
+//WithSharedMutex returns function for serializing writes of the logger on mu,
+//so that independent loggers sharing mu do not interleave their events in the same destination (e.g. file).
+func WithSharedMutex(mu *sync.Mutex) OptFunc {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.shared = mu
+	}
+}
+
+//writeLevel writes a formatted logging event to w (by WriteLevel method if w is LevelWriter).
+func writeLevel(w io.Writer, lv Level, b []byte) error {
+	if lw, ok := w.(LevelWriter); ok {
+		_, err := lw.WriteLevel(lv, b)
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+//writeText writes a logging event in text format.
+//Each line of multi-line message is written as a separate entry if WithMultilinePrefix option is set.
+func (l *Logger) writeText(lv Level, calldepth int, s string) error {
+	_, self := l.lg.Writer().(LevelWriter)
+	l.mu.Lock()
+	self = self || len(l.routes) > 0 || l.prefixFunc != nil || l.callerLevel != nil || l.compact || l.relative || l.deltaTime || l.now != nil // log.Logger cannot change prefix and flags by event
+	shared := l.shared
+	hd := l.header(lv, l.colorEnabled())
+	l.mu.Unlock()
+	for _, line := range l.splitLines(s) {
+		var err error
+		if self {
+			err = l.formatText(lv, calldepth, line)
+		} else {
+			err = l.outputShared(shared, calldepth+1, hd+line)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//outputShared calls l.lg.Output() holding l.mu and shared mutex (if not nil),
+//so that it is serialized with other writes of the logger (e.g. Block, Raw and Check methods).
+func (l *Logger) outputShared(shared *sync.Mutex, calldepth int, s string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if shared != nil {
+		shared.Lock()
+		defer shared.Unlock()
+	}
+	return l.lg.Output(calldepth, s)
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
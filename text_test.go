@@ -0,0 +1,103 @@
+package logf
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type testLevelWriter struct {
+	bytes.Buffer
+}
+
+func (w *testLevelWriter) WriteLevel(lv Level, p []byte) (int, error) {
+	fmt.Fprintf(&w.Buffer, "%d|", int(lv))
+	return w.Write(p)
+}
+
+func TestTextHeader(t *testing.T) {
+	tm := time.Date(2009, time.November, 10, 23, 0, 0, 123456789, time.FixedZone("JST", 9*60*60))
+	testCase := []struct {
+		flag int
+		s    string
+	}{
+		{flag: 0, s: "[TEST] "},
+		{flag: Ldate, s: "[TEST] 2009/11/10 "},
+		{flag: Ltime, s: "[TEST] 23:00:00 "},
+		{flag: Lmicroseconds, s: "[TEST] 23:00:00.123456 "},
+		{flag: LstdFlags | LUTC, s: "[TEST] 2009/11/10 14:00:00 "},
+		{flag: Lshortfile, s: "[TEST] file.go:23: "},
+		{flag: Llongfile, s: "[TEST] /a/b/c/file.go:23: "},
+		{flag: Ldate | Lmicroseconds | Lshortfile, s: "[TEST] 2009/11/10 23:00:00.123456 file.go:23: "},
+	}
+	for _, tst := range testCase {
+		s := textHeader("[TEST] ", tst.flag, tm, "/a/b/c/file.go", 23)
+		if s != tst.s {
+			t.Errorf("textHeader(%#x)  = \"%v\", want \"%v\".", tst.flag, s, tst.s)
+		}
+	}
+}
+
+func TestLevelWriter(t *testing.T) {
+	w := &testLevelWriter{}
+	l := New(
+		WithWriter(w),
+		WithFlags(Llevel|Lshortfile),
+		WithPrefix("[TEST] "),
+	)
+	l.Warn("Warning")
+	l.Errorf("%s", "Erroring")
+	res := "3|[TEST] text_test.go:49: [WARN] Warning\n4|[TEST] text_test.go:50: [ERROR] Erroring\n"
+	if s := w.String(); s != res {
+		t.Errorf("Logger output = \"%v\", want \"%v\".", s, res)
+	}
+
+	w2 := &testLevelWriter{}
+	l2 := New(
+		WithWriter(w2),
+		WithFormatter(testFormatter{}),
+	)
+	l2.Warn("Warning")
+	res2 := "3|WARN|Warning\n"
+	if s := w2.String(); s != res2 {
+		t.Errorf("Logger output = \"%v\", want \"%v\".", s, res2)
+	}
+}
+
+func TestWriteTextSetFlags(t *testing.T) {
+	l := New(WithWriter(new(bytes.Buffer)), WithFlags(Llevel))
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				l.SetFlags(Llevel | (i%2)*Ltime)
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		l.Print("hello")
+	}
+	close(stop)
+	<-done
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
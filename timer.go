@@ -0,0 +1,35 @@
+package logf
+
+//Timer starts timer and returns function which prints elapsed time at level lv
+//(e.g. "operation took 12ms"). Clock of WithClock option is used if set.
+//
+//	defer l.Timer(logf.INFO, "operation")()
+func (l *Logger) Timer(lv Level, name string) func() {
+	l.mu.Lock()
+	start := l.clock()
+	l.mu.Unlock()
+	return func() {
+		l.mu.Lock()
+		d := l.clock().Sub(start)
+		l.mu.Unlock()
+		_ = l.Output(lv, l.depth-1, name+" took "+d.String())
+	}
+}
+
+//Timer calls std.Timer() to start timer.
+func Timer(lv Level, name string) func() { return std.Timer(lv, name) }
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,34 @@
+package logf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestTimer(t *testing.T) {
+	start := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	clock := testClock(start, start.Add(12*time.Millisecond))
+	buf := new(bytes.Buffer)
+	l := New(WithWriter(buf), WithFlags(Llevel|Lshortfile), WithClock(clock))
+	done := l.Timer(WARN, "operation")
+	done()
+	if s, want := buf.String(), "timer_test.go:15: [WARN] operation took 12ms\n"; s != want {
+		t.Errorf("Timer() = \"%v\", want \"%v\".", s, want)
+	}
+}
+
+/* Copyright 2019 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */